@@ -0,0 +1,392 @@
+/*
+ */
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/senzing-garage/go-cmdhelping/cmdhelper"
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-cmdhelping/option/optiontype"
+	"github.com/senzing-garage/init-database/initializer"
+	"github.com/senzing-garage/init-database/senzingconfig"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	envarConfigID   string = "SENZING_TOOLS_CONFIG_ID"
+	envarNormalize  string = "SENZING_TOOLS_NORMALIZE"
+	envarOutputFile string = "SENZING_TOOLS_OUTPUT_FILE"
+	envarPretty     string = "SENZING_TOOLS_PRETTY"
+	envarRollbackTo string = "SENZING_TOOLS_ROLLBACK_TO"
+)
+
+// ----------------------------------------------------------------------------
+// Context variables
+// ----------------------------------------------------------------------------
+
+var OptionConfigID = option.ContextVariable{
+	Arg:     "config-id",
+	Default: option.OsLookupEnvInt(envarConfigID, 0),
+	Envar:   envarConfigID,
+	Help:    "Identifier of a Senzing configuration in SYS_CFG. Default: the default configuration [%s]",
+	Type:    optiontype.Int,
+}
+
+var OptionNormalize = option.ContextVariable{
+	Arg:     "normalize",
+	Default: option.OsLookupEnvBool(envarNormalize, false),
+	Envar:   envarNormalize,
+	Help:    "Sort JSON keys and remove the Senzing build fields of CONFIG_BASE_VERSION, for version control and comparison [%s]",
+	Type:    optiontype.Bool,
+}
+
+var OptionOutputFile = option.ContextVariable{
+	Arg:     "output-file",
+	Default: option.OsLookupEnvString(envarOutputFile, ""),
+	Envar:   envarOutputFile,
+	Help:    "Path to the file to write. Default: standard output [%s]",
+	Type:    optiontype.String,
+}
+
+var OptionPretty = option.ContextVariable{
+	Arg:     "pretty",
+	Default: option.OsLookupEnvBool(envarPretty, false),
+	Envar:   envarPretty,
+	Help:    "Indent the JSON written [%s]",
+	Type:    optiontype.Bool,
+}
+
+var OptionRollbackTo = option.ContextVariable{
+	Arg:     "to",
+	Default: option.OsLookupEnvInt(envarRollbackTo, 0),
+	Envar:   envarRollbackTo,
+	Help:    "Identifier of the Senzing configuration in SYS_CFG to make the default [%s]",
+	Type:    optiontype.Int,
+}
+
+var configExportContextVariables = append(append([]option.ContextVariable{}, ContextVariables...), OptionConfigID, OptionNormalize, OptionOutputFile, OptionPretty)
+
+var configDiffContextVariables = append(append([]option.ContextVariable{}, ContextVariables...), OptionOutputFormat)
+
+var configListContextVariables = append(append([]option.ContextVariable{}, ContextVariables...), OptionOutputFormat)
+
+var configRollbackContextVariables = append(append([]option.ContextVariable{}, ContextVariables...), OptionRollbackTo)
+
+// ----------------------------------------------------------------------------
+// Command
+// ----------------------------------------------------------------------------
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Work with the Senzing configurations in a Senzing repository",
+	Long: `
+Work with the Senzing configurations registered in the SYS_CFG table of a Senzing repository.
+`,
+}
+
+// configDiffCmd represents the config diff command
+var configDiffCmd = &cobra.Command{
+	Use:   "diff FIRST SECOND",
+	Short: "Report differences between two Senzing configurations",
+	Long: `
+Report how the SECOND Senzing configuration differs from the FIRST, section by section.
+Datasources, features, attributes and rules are matched by code and compared field by field;
+the other tables of the configuration are reported when they differ at all.
+Each of FIRST and SECOND is the path of a Senzing configuration JSON file,
+a Senzing configuration identifier in SYS_CFG, or "default" for the default configuration.
+`,
+	Args: cobra.ExactArgs(2),
+	PreRun: func(cobraCommand *cobra.Command, args []string) {
+		cmdhelper.PreRun(cobraCommand, args, Use, configDiffContextVariables)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return configDiffAction(context.Background(), viper.GetViper(), cmd.OutOrStdout(), args[0], args[1])
+	},
+	SilenceUsage: true,
+}
+
+// configExportCmd represents the config export command
+var configExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write a Senzing configuration as JSON",
+	Long: `
+Write the default Senzing configuration, or the one identified by config-id, as JSON.
+With normalize, JSON keys are sorted and fields that differ only by Senzing build are removed,
+so exports from different environments can be version-controlled and compared.
+`,
+	PreRun: func(cobraCommand *cobra.Command, args []string) {
+		cmdhelper.PreRun(cobraCommand, args, Use, configExportContextVariables)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_ = args
+		return configExportAction(context.Background(), viper.GetViper(), cmd.OutOrStdout())
+	},
+	SilenceUsage: true,
+}
+
+// configListCmd represents the config list command
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the Senzing configurations in SYS_CFG",
+	Long: `
+List the Senzing configurations registered in the SYS_CFG table, oldest first,
+with their identifier, comment, creation time and number of datasources.
+The default configuration is marked.
+`,
+	PreRun: func(cobraCommand *cobra.Command, args []string) {
+		cmdhelper.PreRun(cobraCommand, args, Use, configListContextVariables)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_ = args
+		return configListAction(context.Background(), viper.GetViper(), cmd.OutOrStdout())
+	},
+	SilenceUsage: true,
+}
+
+// configRollbackCmd represents the config rollback command
+var configRollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Make an earlier Senzing configuration the default",
+	Long: `
+Make the Senzing configuration identified by --to the default.
+The configuration must already be registered in the SYS_CFG table; see "config list".
+`,
+	PreRun: func(cobraCommand *cobra.Command, args []string) {
+		cmdhelper.PreRun(cobraCommand, args, Use, configRollbackContextVariables)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_ = cmd
+		_ = args
+		return configRollbackAction(context.Background(), viper.GetViper())
+	},
+	SilenceUsage: true,
+}
+
+func init() {
+	RootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configDiffCmd)
+	cmdhelper.Init(configDiffCmd, configDiffContextVariables)
+	configCmd.AddCommand(configExportCmd)
+	cmdhelper.Init(configExportCmd, configExportContextVariables)
+	configCmd.AddCommand(configListCmd)
+	cmdhelper.Init(configListCmd, configListContextVariables)
+	configCmd.AddCommand(configRollbackCmd)
+	cmdhelper.Init(configRollbackCmd, configRollbackContextVariables)
+}
+
+func configDiffAction(ctx context.Context, aViper *viper.Viper, writer io.Writer, first string, second string) error {
+	outputFormat := aViper.GetString(OptionOutputFormat.Arg)
+	if outputFormat != outputFormatText && outputFormat != outputFormatJSON {
+		return fmt.Errorf("invalid %s: %s", OptionOutputFormat.Arg, outputFormat)
+	}
+	var senzingConfig *senzingconfig.BasicSenzingConfig
+	configDefinitions := []string{}
+	for _, configSource := range []string{first, second} {
+		configDefinition, err := os.ReadFile(filepath.Clean(configSource))
+		if err == nil {
+			configDefinitions = append(configDefinitions, string(configDefinition))
+			continue
+		}
+		configID, isConfigID := parseConfigSource(configSource)
+		if !isConfigID {
+			return err
+		}
+		if senzingConfig == nil {
+			senzingConfig, err = getSenzingConfig(ctx, aViper)
+			if err != nil {
+				return err
+			}
+		}
+		exported, err := senzingConfig.ExportConfig(ctx, configID)
+		if err != nil {
+			return err
+		}
+		configDefinitions = append(configDefinitions, exported)
+	}
+	differences, err := senzingconfig.CompareConfigs(configDefinitions[0], configDefinitions[1])
+	if err != nil {
+		return err
+	}
+	return writeConfigDifferences(writer, outputFormat, differences)
+}
+
+func configExportAction(ctx context.Context, aViper *viper.Viper, writer io.Writer) error {
+	senzingConfig, err := getSenzingConfig(ctx, aViper)
+	if err != nil {
+		return err
+	}
+	configDefinition, err := senzingConfig.ExportConfig(ctx, aViper.GetInt64(OptionConfigID.Arg))
+	if err != nil {
+		return err
+	}
+	configDefinition, err = formatConfig(configDefinition, aViper.GetBool(OptionNormalize.Arg), aViper.GetBool(OptionPretty.Arg))
+	if err != nil {
+		return err
+	}
+	outputFile := aViper.GetString(OptionOutputFile.Arg)
+	if len(outputFile) == 0 {
+		_, err = fmt.Fprintln(writer, configDefinition)
+		return err
+	}
+	return os.WriteFile(filepath.Clean(outputFile), []byte(configDefinition+"\n"), 0600)
+}
+
+func configListAction(ctx context.Context, aViper *viper.Viper, writer io.Writer) error {
+	outputFormat := aViper.GetString(OptionOutputFormat.Arg)
+	if outputFormat != outputFormatText && outputFormat != outputFormatJSON {
+		return fmt.Errorf("invalid %s: %s", OptionOutputFormat.Arg, outputFormat)
+	}
+	senzingConfig, err := getSenzingConfig(ctx, aViper)
+	if err != nil {
+		return err
+	}
+	summaries, err := senzingConfig.ListConfigs(ctx)
+	if err != nil {
+		return err
+	}
+	return writeConfigSummaries(writer, outputFormat, summaries)
+}
+
+func configRollbackAction(ctx context.Context, aViper *viper.Viper) error {
+	configID := aViper.GetInt64(OptionRollbackTo.Arg)
+	if configID == 0 {
+		return fmt.Errorf("--%s is required", OptionRollbackTo.Arg)
+	}
+	senzingConfig, err := getSenzingConfig(ctx, aViper)
+	if err != nil {
+		return err
+	}
+	return senzingConfig.RollbackConfig(ctx, configID)
+}
+
+// A Senzing configuration handler for the Senzing repository named by the command's options.
+func getSenzingConfig(ctx context.Context, aViper *viper.Viper) (*senzingconfig.BasicSenzingConfig, error) {
+	senzingSettings, err := getSenzingSettings(ctx, aViper)
+	if err != nil {
+		return nil, err
+	}
+	senzingConfig := &senzingconfig.BasicSenzingConfig{
+		SenzingInstanceName:   aViper.GetString(option.EngineInstanceName.Arg),
+		SenzingSettings:       senzingSettings,
+		SenzingVerboseLogging: aViper.GetInt64(option.EngineLogLevel.Arg),
+	}
+	err = senzingConfig.SetLogLevel(ctx, aViper.GetString(option.LogLevel.Arg))
+	if err != nil {
+		return nil, err
+	}
+	senzingConfig.SetObserverOrigin(ctx, aViper.GetString(option.ObserverOrigin.Arg))
+	observerURL := aViper.GetString(option.ObserverURL.Arg)
+	if len(observerURL) > 0 {
+		anObserver, err := initializer.NewObserver(ctx, observerURL)
+		if err != nil {
+			return nil, err
+		}
+		if anObserver != nil {
+			err = senzingConfig.RegisterObserver(ctx, anObserver)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return senzingConfig, err
+}
+
+// The Senzing configuration identifier named by a config diff argument that is not a file: "default" or a number.
+func parseConfigSource(configSource string) (int64, bool) {
+	if configSource == "default" {
+		return 0, true
+	}
+	configID, err := strconv.ParseInt(configSource, 10, 64)
+	return configID, err == nil && configID > 0
+}
+
+// Optionally normalize, then optionally indent, a Senzing configuration JSON.
+func formatConfig(configDefinition string, normalize bool, pretty bool) (string, error) {
+	var err error
+	if normalize {
+		configDefinition, err = senzingconfig.NormalizeConfig(configDefinition)
+		if err != nil {
+			return "", err
+		}
+	}
+	if pretty {
+		indented := &bytes.Buffer{}
+		err = json.Indent(indented, []byte(configDefinition), "", "    ")
+		if err != nil {
+			return "", err
+		}
+		configDefinition = indented.String()
+	}
+	return configDefinition, err
+}
+
+// Write a list of Senzing configurations as a text table or JSON.
+func writeConfigSummaries(writer io.Writer, outputFormat string, summaries []senzingconfig.ConfigSummary) error {
+	if outputFormat == outputFormatJSON {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "    ")
+		return encoder.Encode(summaries)
+	}
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, err := fmt.Fprintln(table, "DEFAULT\tCONFIG ID\tCREATED\tDATASOURCES\tCOMMENT")
+	if err != nil {
+		return err
+	}
+	for _, summary := range summaries {
+		isDefault := ""
+		if summary.IsDefault {
+			isDefault = "*"
+		}
+		_, err = fmt.Fprintf(table, "%s\t%d\t%s\t%d\t%s\n", isDefault, summary.ConfigID, summary.CreateTime, summary.DataSourceCount, summary.Comments)
+		if err != nil {
+			return err
+		}
+	}
+	return table.Flush()
+}
+
+// Write Senzing configuration differences as text or JSON.
+func writeConfigDifferences(writer io.Writer, outputFormat string, differences []senzingconfig.ConfigDifference) error {
+	var err error
+	if outputFormat == outputFormatJSON {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "    ")
+		return encoder.Encode(differences)
+	}
+	if len(differences) == 0 {
+		_, err = fmt.Fprintln(writer, "No differences found.")
+		return err
+	}
+	for _, difference := range differences {
+		_, err = fmt.Fprintf(writer, "%s: %s %s\n", difference.Section, difference.Change, difference.Key)
+		if err != nil {
+			return err
+		}
+		for _, field := range difference.Fields {
+			_, err = fmt.Fprintf(writer, "    %s: %s -> %s\n", field.Field, describeConfigValue(field.Before), describeConfigValue(field.After))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// A JSON value from a Senzing configuration, or "(none)" for a field that is absent.
+func describeConfigValue(value string) string {
+	if len(value) == 0 {
+		return "(none)"
+	}
+	return value
+}
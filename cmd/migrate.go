@@ -0,0 +1,98 @@
+/*
+ */
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/senzing-garage/go-cmdhelping/cmdhelper"
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-cmdhelping/option/optiontype"
+	"github.com/senzing-garage/init-database/initializer"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	envarMigrationDirectory  string = "SENZING_TOOLS_MIGRATION_DIRECTORY"
+	envarTargetSchemaVersion string = "SENZING_TOOLS_TARGET_SCHEMA_VERSION"
+)
+
+// ----------------------------------------------------------------------------
+// Context variables
+// ----------------------------------------------------------------------------
+
+var OptionMigrationDirectory = option.ContextVariable{
+	Arg:     "migration-directory",
+	Default: option.OsLookupEnvString(envarMigrationDirectory, ""),
+	Envar:   envarMigrationDirectory,
+	Help:    "Path to directory of per-database subdirectories of schema migration SQL files [%s]",
+	Type:    optiontype.String,
+}
+
+var OptionTargetSchemaVersion = option.ContextVariable{
+	Arg:     "target-schema-version",
+	Default: option.OsLookupEnvString(envarTargetSchemaVersion, ""),
+	Envar:   envarTargetSchemaVersion,
+	Help:    "Senzing schema version to migrate to. Default: latest available [%s]",
+	Type:    optiontype.String,
+}
+
+var migrateContextVariables = append(append([]option.ContextVariable{}, ContextVariables...), OptionAdminDatabaseURL, OptionAdminDatabaseURLFile, OptionLockTimeoutInSeconds, OptionMigrationDirectory, OptionSQLIndexTablespace, OptionSQLSchema, OptionSQLTableOptions, OptionSQLTablespace, OptionSQLVariablesFile, OptionTargetSchemaVersion)
+
+// ----------------------------------------------------------------------------
+// Command
+// ----------------------------------------------------------------------------
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate the Senzing database schema to a newer version",
+	Long: `
+Apply schema migration files, in order, to bring existing Senzing databases to a newer schema version.
+Migration files are named "<from-version>-<to-version>.sql" and are found in a subdirectory
+named for the database type (mssql, mysql, oci, postgresql, sqlite3) of the migration directory.
+Applied migrations are recorded in the INIT_DATABASE_MIGRATIONS table.
+`,
+	PreRun: func(cobraCommand *cobra.Command, args []string) {
+		cmdhelper.PreRun(cobraCommand, args, Use, migrateContextVariables)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_ = cmd
+		_ = args
+		return migrateAction(context.Background(), viper.GetViper())
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(migrateCmd)
+	cmdhelper.Init(migrateCmd, migrateContextVariables)
+}
+
+func migrateAction(ctx context.Context, aViper *viper.Viper) error {
+	senzingSettings, err := getSenzingSettings(ctx, aViper)
+	if err != nil {
+		return err
+	}
+	sqlVariables, err := getSQLVariables(aViper)
+	if err != nil {
+		return err
+	}
+	adminDatabaseURL, err := getSecret(aViper, OptionAdminDatabaseURL, OptionAdminDatabaseURLFile)
+	if err != nil {
+		return err
+	}
+	initializer := &initializer.BasicInitializer{
+		AdminDatabaseURL:    adminDatabaseURL,
+		LockTimeout:         time.Duration(aViper.GetInt(OptionLockTimeoutInSeconds.Arg)) * time.Second,
+		MigrationDirectory:  aViper.GetString(OptionMigrationDirectory.Arg),
+		ObserverOrigin:      aViper.GetString(option.ObserverOrigin.Arg),
+		ObserverURL:         aViper.GetString(option.ObserverURL.Arg),
+		SenzingLogLevel:     aViper.GetString(option.LogLevel.Arg),
+		SenzingSettings:     senzingSettings,
+		SQLVariables:        sqlVariables,
+		TargetSchemaVersion: aViper.GetString(OptionTargetSchemaVersion.Arg),
+	}
+	return initializer.Migrate(ctx)
+}
@@ -4,27 +4,50 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/senzing-garage/go-cmdhelping/cmdhelper"
 	"github.com/senzing-garage/go-cmdhelping/constant"
 	"github.com/senzing-garage/go-cmdhelping/option"
 	"github.com/senzing-garage/go-cmdhelping/option/optiontype"
-	"github.com/senzing-garage/go-databasing/dbhelper"
 	"github.com/senzing-garage/go-helpers/settings"
 	"github.com/senzing-garage/go-helpers/settingsparser"
 	"github.com/senzing-garage/init-database/initializer"
+	"github.com/senzing-garage/init-database/senzingschema"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 const (
-	envarEngineConfigurationFile        = "SENZING_TOOLS_ENGINE_CONFIGURATION_FILE"
-	envarSQLFile                 string = "SENZING_TOOLS_SQL_FILE"
-	Short                        string = "Initialize a database with the Senzing schema and configuration"
-	Use                          string = "init-database"
+	envarAdminDatabaseURL                   = "SENZING_TOOLS_ADMIN_DATABASE_URL"
+	envarCreateDatabase                     = "SENZING_TOOLS_CREATE_DATABASE"
+	envarDatabaseCollation                  = "SENZING_TOOLS_DATABASE_COLLATION"
+	envarDatabaseEncoding                   = "SENZING_TOOLS_DATABASE_ENCODING"
+	envarDatabaseRetries                    = "SENZING_TOOLS_DATABASE_RETRIES"
+	envarDatabaseRetryDelayInSeconds        = "SENZING_TOOLS_DATABASE_RETRY_DELAY_IN_SECONDS"
+	envarDatabaseSchema                     = "SENZING_TOOLS_DATABASE_SCHEMA"
+	envarDatabaseTimeoutInSeconds           = "SENZING_TOOLS_DATABASE_TIMEOUT_IN_SECONDS"
+	envarDeleteUnusedDatasources            = "SENZING_TOOLS_DELETE_UNUSED_DATASOURCES"
+	envarDryRun                             = "SENZING_TOOLS_DRY_RUN"
+	envarEngineConfigurationFile            = "SENZING_TOOLS_ENGINE_CONFIGURATION_FILE"
+	envarLockTimeoutInSeconds               = "SENZING_TOOLS_LOCK_TIMEOUT_IN_SECONDS"
+	envarReconcileDatasources               = "SENZING_TOOLS_RECONCILE_DATASOURCES"
+	envarRuntimePassword                    = "SENZING_TOOLS_RUNTIME_PASSWORD"
+	envarRuntimeUser                        = "SENZING_TOOLS_RUNTIME_USER"
+	envarSQLFile                     string = "SENZING_TOOLS_SQL_FILE"
+	envarSQLFileMap                  string = "SENZING_TOOLS_SQL_FILE_MAP"
+	envarSQLIndexTablespace                 = "SENZING_TOOLS_SQL_INDEX_TABLESPACE"
+	envarSQLSchema                          = "SENZING_TOOLS_SQL_SCHEMA"
+	envarSQLTableOptions                    = "SENZING_TOOLS_SQL_TABLE_OPTIONS"
+	envarSQLTablespace                      = "SENZING_TOOLS_SQL_TABLESPACE"
+	envarSQLVariablesFile                   = "SENZING_TOOLS_SQL_VARIABLES_FILE"
+	Short                            string = "Initialize a database with the Senzing schema and configuration"
+	Use                              string = "init-database"
 )
 
 var (
@@ -35,30 +58,192 @@ var (
 // Context variables
 // ----------------------------------------------------------------------------
 
+var OptionAdminDatabaseURL = option.ContextVariable{
+	Arg:     "admin-database-url",
+	Default: option.OsLookupEnvString(envarAdminDatabaseURL, ""),
+	Envar:   envarAdminDatabaseURL,
+	Help:    "URL of the database for an account allowed to run DDL. When set, the runtime user is granted only DML access [%s]",
+	Type:    optiontype.String,
+}
+
+var OptionCreateDatabase = option.ContextVariable{
+	Arg:     "create-database",
+	Default: option.OsLookupEnvBool(envarCreateDatabase, false),
+	Envar:   envarCreateDatabase,
+	Help:    "Create the PostgreSQL, MySQL or MS SQL database named in the database URL if it does not exist [%s]",
+	Type:    optiontype.Bool,
+}
+
+var OptionDatabaseCollation = option.ContextVariable{
+	Arg:     "database-collation",
+	Default: option.OsLookupEnvString(envarDatabaseCollation, ""),
+	Envar:   envarDatabaseCollation,
+	Help:    "Collation of a database created by create-database. Default: the server's default [%s]",
+	Type:    optiontype.String,
+}
+
+var OptionDatabaseEncoding = option.ContextVariable{
+	Arg:     "database-encoding",
+	Default: option.OsLookupEnvString(envarDatabaseEncoding, ""),
+	Envar:   envarDatabaseEncoding,
+	Help:    "Encoding (character set) of a PostgreSQL or MySQL database created by create-database. Default: the server's default [%s]",
+	Type:    optiontype.String,
+}
+
+var OptionDatabaseRetries = option.ContextVariable{
+	Arg:     "database-retries",
+	Default: option.OsLookupEnvInt(envarDatabaseRetries, 0),
+	Envar:   envarDatabaseRetries,
+	Help:    "Number of times to retry connecting to a database that is not ready [%s]",
+	Type:    optiontype.Int,
+}
+
+var OptionDatabaseRetryDelayInSeconds = option.ContextVariable{
+	Arg:     "database-retry-delay-in-seconds",
+	Default: option.OsLookupEnvInt(envarDatabaseRetryDelayInSeconds, 1),
+	Envar:   envarDatabaseRetryDelayInSeconds,
+	Help:    "Seconds to wait before the first retry. Doubled for each later retry [%s]",
+	Type:    optiontype.Int,
+}
+
+var OptionDatabaseSchema = option.ContextVariable{
+	Arg:     "database-schema",
+	Default: option.OsLookupEnvString(envarDatabaseSchema, ""),
+	Envar:   envarDatabaseSchema,
+	Help:    "PostgreSQL schema to create the Senzing tables in. The database URL must name the same schema, as in ?schema=name [%s]",
+	Type:    optiontype.String,
+}
+
+var OptionDatabaseTimeoutInSeconds = option.ContextVariable{
+	Arg:     "database-timeout-in-seconds",
+	Default: option.OsLookupEnvInt(envarDatabaseTimeoutInSeconds, 0),
+	Envar:   envarDatabaseTimeoutInSeconds,
+	Help:    "Seconds to wait for each connection attempt. 0 with no retries means databases are not probed [%s]",
+	Type:    optiontype.Int,
+}
+
+var OptionDeleteUnusedDatasources = option.ContextVariable{
+	Arg:     "delete-unused-datasources",
+	Default: option.OsLookupEnvBool(envarDeleteUnusedDatasources, false),
+	Envar:   envarDeleteUnusedDatasources,
+	Help:    "With reconcile-datasources, delete datasources not listed in datasources that have no records [%s]",
+	Type:    optiontype.Bool,
+}
+
+var OptionDryRun = option.ContextVariable{
+	Arg:     "dry-run",
+	Default: option.OsLookupEnvBool(envarDryRun, false),
+	Envar:   envarDryRun,
+	Help:    "Print the SQL statements and configuration changes without making them [%s]",
+	Type:    optiontype.Bool,
+}
+
 var OptionEngineConfigurationFile = option.ContextVariable{
 	Arg:     "engine-configuration-file",
 	Default: getEngineConfigurationFileDefault(),
 	Envar:   envarEngineConfigurationFile,
-	Help:    "Path to file of JSON used to configure Senzing engine [%s]",
+	Help:    "Path to a complete Senzing configuration JSON file imported as the initial Senzing configuration [%s]",
+	Type:    optiontype.String,
+}
+
+var OptionLockTimeoutInSeconds = option.ContextVariable{
+	Arg:     "lock-timeout-in-seconds",
+	Default: option.OsLookupEnvInt(envarLockTimeoutInSeconds, 0),
+	Envar:   envarLockTimeoutInSeconds,
+	Help:    "Seconds to wait while another init-database holds an initialization lock. 0, the default, waits forever [%s]",
+	Type:    optiontype.Int,
+}
+
+var OptionReconcileDatasources = option.ContextVariable{
+	Arg:     "reconcile-datasources",
+	Default: option.OsLookupEnvBool(envarReconcileDatasources, false),
+	Envar:   envarReconcileDatasources,
+	Help:    "When a Senzing configuration already exists, add missing datasources to it and make the result the default [%s]",
+	Type:    optiontype.Bool,
+}
+
+var OptionRuntimePassword = option.ContextVariable{
+	Arg:     "runtime-password",
+	Default: option.OsLookupEnvString(envarRuntimePassword, ""),
+	Envar:   envarRuntimePassword,
+	Help:    "Password of a runtime user created by admin-database-url [%s]",
+	Type:    optiontype.String,
+}
+
+var OptionRuntimeUser = option.ContextVariable{
+	Arg:     "runtime-user",
+	Default: option.OsLookupEnvString(envarRuntimeUser, ""),
+	Envar:   envarRuntimeUser,
+	Help:    "User Senzing runs as, granted DML access when admin-database-url is set. Default: the user in the database URL [%s]",
 	Type:    optiontype.String,
 }
 
 var OptionSQLFile = option.ContextVariable{
 	Arg:     "sql-file",
-	Default: getSQLFileDefault(),
+	Default: option.OsLookupEnvString(envarSQLFile, ""),
 	Envar:   envarSQLFile,
-	Help:    "Path to file of SQL used to create Senzing database schema [%s]",
+	Help:    "Path to file of SQL used to create Senzing database schema in every database. Default: chosen per database URL [%s]",
+	Type:    optiontype.String,
+}
+
+var OptionSQLFileMap = option.ContextVariable{
+	Arg:     "sql-file-map",
+	Default: option.OsLookupEnvString(envarSQLFileMap, ""),
+	Envar:   envarSQLFileMap,
+	Help:    "JSON object mapping database URLs to paths of SQL files, overriding sql-file for those databases [%s]",
+	Type:    optiontype.String,
+}
+
+var OptionSQLIndexTablespace = option.ContextVariable{
+	Arg:     "sql-index-tablespace",
+	Default: option.OsLookupEnvString(envarSQLIndexTablespace, ""),
+	Envar:   envarSQLIndexTablespace,
+	Help:    "Value of {{.IndexTablespace}} in SQL files [%s]",
+	Type:    optiontype.String,
+}
+
+var OptionSQLSchema = option.ContextVariable{
+	Arg:     "sql-schema",
+	Default: option.OsLookupEnvString(envarSQLSchema, ""),
+	Envar:   envarSQLSchema,
+	Help:    "Value of {{.Schema}} in SQL files [%s]",
+	Type:    optiontype.String,
+}
+
+var OptionSQLTableOptions = option.ContextVariable{
+	Arg:     "sql-table-options",
+	Default: option.OsLookupEnvString(envarSQLTableOptions, ""),
+	Envar:   envarSQLTableOptions,
+	Help:    "Value of {{.TableOptions}} in SQL files [%s]",
+	Type:    optiontype.String,
+}
+
+var OptionSQLTablespace = option.ContextVariable{
+	Arg:     "sql-tablespace",
+	Default: option.OsLookupEnvString(envarSQLTablespace, ""),
+	Envar:   envarSQLTablespace,
+	Help:    "Value of {{.Tablespace}} in SQL files [%s]",
+	Type:    optiontype.String,
+}
+
+var OptionSQLVariablesFile = option.ContextVariable{
+	Arg:     "sql-variables-file",
+	Default: option.OsLookupEnvString(envarSQLVariablesFile, ""),
+	Envar:   envarSQLVariablesFile,
+	Help:    "Path to file of a JSON object of variables for SQL files, which are Go templates. The sql-schema, sql-tablespace, sql-index-tablespace and sql-table-options options take precedence [%s]",
 	Type:    optiontype.String,
 }
 
 var ContextVariablesForMultiPlatform = []option.ContextVariable{
 	option.Configuration,
 	option.DatabaseURL,
+	OptionDatabaseURLFile,
 	option.Datasources,
 	option.EngineSettings,
 	option.EngineLogLevel,
 	option.EngineInstanceName,
 	option.LicenseStringBase64,
+	OptionLicenseStringBase64File,
 	option.LogLevel,
 	option.ObserverOrigin,
 	option.ObserverURL,
@@ -95,7 +280,7 @@ func Execute() {
 
 // Used in construction of cobra.Command
 func PreRun(cobraCommand *cobra.Command, args []string) {
-	cmdhelper.PreRun(cobraCommand, args, Use, append(ContextVariables, OptionSQLFile, OptionEngineConfigurationFile))
+	cmdhelper.PreRun(cobraCommand, args, Use, append(ContextVariables, OptionAdminDatabaseURL, OptionAdminDatabaseURLFile, OptionCreateDatabase, OptionDatabaseCollation, OptionDatabaseEncoding, OptionDatabaseRetries, OptionDatabaseRetryDelayInSeconds, OptionDatabaseSchema, OptionDatabaseTimeoutInSeconds, OptionDeleteUnusedDatasources, OptionDryRun, OptionLockTimeoutInSeconds, OptionReconcileDatasources, OptionRuntimePassword, OptionRuntimePasswordFile, OptionRuntimeUser, OptionSQLFile, OptionSQLFileMap, OptionSQLIndexTablespace, OptionSQLSchema, OptionSQLTableOptions, OptionSQLTablespace, OptionSQLVariablesFile, OptionEngineConfigurationFile))
 }
 
 // Used in construction of cobra.Command
@@ -103,21 +288,57 @@ func RunE(_ *cobra.Command, _ []string) error {
 	var err error
 	ctx := context.Background()
 
-	senzingSettings, err := buildSenzingEngineConfigurationJSON(ctx, viper.GetViper())
+	senzingSettings, err := getSenzingSettings(ctx, viper.GetViper())
+	if err != nil {
+		return err
+	}
+
+	sqlFileMap, err := getSQLFileMap(viper.GetViper())
+	if err != nil {
+		return err
+	}
+
+	sqlVariables, err := getSQLVariables(viper.GetViper())
+	if err != nil {
+		return err
+	}
+
+	adminDatabaseURL, err := getSecret(viper.GetViper(), OptionAdminDatabaseURL, OptionAdminDatabaseURLFile)
+	if err != nil {
+		return err
+	}
+
+	runtimePassword, err := getSecret(viper.GetViper(), OptionRuntimePassword, OptionRuntimePasswordFile)
 	if err != nil {
 		return err
 	}
 
 	initializer := &initializer.BasicInitializer{
-		DataSources:           viper.GetStringSlice(option.Datasources.Arg),
-		ObserverOrigin:        viper.GetString(option.ObserverOrigin.Arg),
-		ObserverURL:           viper.GetString(option.ObserverURL.Arg),
-		SenzingInstanceName:   viper.GetString(option.EngineInstanceName.Arg),
-		SenzingLogLevel:       viper.GetString(option.LogLevel.Arg),
-		SenzingSettings:       senzingSettings,
-		SenzingSettingsFile:   viper.GetString(OptionEngineConfigurationFile.Arg),
-		SenzingVerboseLogging: viper.GetInt64(option.EngineLogLevel.Arg),
-		SQLFile:               viper.GetString(OptionSQLFile.Arg),
+		AdminDatabaseURL:        adminDatabaseURL,
+		CreateDatabase:          viper.GetBool(OptionCreateDatabase.Arg),
+		DatabaseCollation:       viper.GetString(OptionDatabaseCollation.Arg),
+		DatabaseEncoding:        viper.GetString(OptionDatabaseEncoding.Arg),
+		DatabaseRetries:         viper.GetInt(OptionDatabaseRetries.Arg),
+		DatabaseRetryDelay:      time.Duration(viper.GetInt(OptionDatabaseRetryDelayInSeconds.Arg)) * time.Second,
+		DatabaseSchema:          viper.GetString(OptionDatabaseSchema.Arg),
+		DatabaseTimeout:         time.Duration(viper.GetInt(OptionDatabaseTimeoutInSeconds.Arg)) * time.Second,
+		DataSources:             viper.GetStringSlice(option.Datasources.Arg),
+		DeleteUnusedDataSources: viper.GetBool(OptionDeleteUnusedDatasources.Arg),
+		DryRun:                  viper.GetBool(OptionDryRun.Arg),
+		LockTimeout:             time.Duration(viper.GetInt(OptionLockTimeoutInSeconds.Arg)) * time.Second,
+		ObserverOrigin:          viper.GetString(option.ObserverOrigin.Arg),
+		ObserverURL:             viper.GetString(option.ObserverURL.Arg),
+		ReconcileDataSources:    viper.GetBool(OptionReconcileDatasources.Arg),
+		RuntimePassword:         runtimePassword,
+		RuntimeUser:             viper.GetString(OptionRuntimeUser.Arg),
+		SenzingInstanceName:     viper.GetString(option.EngineInstanceName.Arg),
+		SenzingLogLevel:         viper.GetString(option.LogLevel.Arg),
+		SenzingSettings:         senzingSettings,
+		SenzingSettingsFile:     viper.GetString(OptionEngineConfigurationFile.Arg),
+		SenzingVerboseLogging:   viper.GetInt64(option.EngineLogLevel.Arg),
+		SQLFile:                 viper.GetString(OptionSQLFile.Arg),
+		SQLFileMap:              sqlFileMap,
+		SQLVariables:            sqlVariables,
 	}
 	return initializer.Initialize(ctx)
 }
@@ -131,16 +352,25 @@ func Version() string {
 // Private functions
 // ----------------------------------------------------------------------------
 
-// Construct the JSON string for the Senzing engine configuration.
-func buildSenzingEngineConfigurationJSON(ctx context.Context, aViper *viper.Viper) (string, error) {
+// Construct the JSON string for the Senzing engine configuration, without resolving references.
+func buildSenzingEngineConfigurationJSON(aViper *viper.Viper) (string, error) {
 	var err error
 	var result string
 	result = aViper.GetString(option.EngineSettings.Arg)
 	if len(result) == 0 {
+		var databaseURL, licenseStringBase64 string
+		databaseURL, err = getSecret(aViper, option.DatabaseURL, OptionDatabaseURLFile)
+		if err != nil {
+			return result, err
+		}
+		licenseStringBase64, err = getSecret(aViper, option.LicenseStringBase64, OptionLicenseStringBase64File)
+		if err != nil {
+			return result, err
+		}
 		options := map[string]string{
 			"configPath":          aViper.GetString(option.ConfigPath.Arg),
-			"databaseURL":         aViper.GetString(option.DatabaseURL.Arg),
-			"licenseStringBase64": aViper.GetString(option.LicenseStringBase64.Arg),
+			"databaseURL":         databaseURL,
+			"licenseStringBase64": licenseStringBase64,
 			"resourcePath":        aViper.GetString(option.ResourcePath.Arg),
 			"senzingDirectory":    aViper.GetString(option.SenzingDirectory.Arg),
 			"supportPath":         aViper.GetString(option.SupportPath.Arg),
@@ -151,10 +381,20 @@ func buildSenzingEngineConfigurationJSON(ctx context.Context, aViper *viper.Vipe
 		}
 	}
 
-	err = settings.VerifySettings(ctx, result)
+	return result, err
+}
+
+// The Senzing settings a command runs with: constructed, with references resolved, and verified.
+func getSenzingSettings(ctx context.Context, aViper *viper.Viper) (string, error) {
+	result, err := buildSenzingEngineConfigurationJSON(aViper)
+	if err != nil {
+		return result, err
+	}
+	result, err = resolveSettingsReferences(result)
 	if err != nil {
 		return result, err
 	}
+	err = settings.VerifySettings(ctx, result)
 	return result, err
 }
 
@@ -191,10 +431,10 @@ Initialize a database with the Senzing schema and configuration.
 For more information, visit https://github.com/senzing-garage/init-database
 	`
 
-	sqlFileDefault := getSQLFileDefault()
-	if len(sqlFileDefault) > 0 {
-		result = fmt.Sprintf("%s\nThe SQL file used to create the Senzing database schema will be %s", result, sqlFileDefault)
-	}
+	result = fmt.Sprintf("%s\nThe SQL file used to create the Senzing database schema is chosen for each database URL:", result)
+	result = fmt.Sprintf("%s\n  1. The --%s entry for the database URL.", result, OptionSQLFileMap.Arg)
+	result = fmt.Sprintf("%s\n  2. The --%s file.", result, OptionSQLFile.Arg)
+	result = fmt.Sprintf("%s\n  3. The file for the database's scheme in <resource path>/schema, e.g. szcore-schema-postgresql-create.sql.", result)
 	engineConfigurationFileDefault := getEngineConfigurationFileDefault()
 	if len(engineConfigurationFileDefault) > 0 {
 		result = fmt.Sprintf("%s\nThe JSON file used to create the Senzing configuration  will be %s", result, engineConfigurationFileDefault)
@@ -202,10 +442,10 @@ For more information, visit https://github.com/senzing-garage/init-database
 	return result
 }
 
-// Create a temporary parsed Senzing engine configuration.
+// Create a temporary parsed Senzing engine configuration, used only for defaults in help text and options.
+// References are left unresolved; getSenzingSettings resolves them, and reports any error, when a command runs.
 func getParsedEngineConfigurationJSON() (settingsparser.SettingsParser, error) {
 	var result settingsparser.SettingsParser
-	ctx := context.Background()
 
 	// Early exit.  Environment variable is set.
 
@@ -230,70 +470,56 @@ func getParsedEngineConfigurationJSON() (settingsparser.SettingsParser, error) {
 
 	// Build and parse Senzing engine configuration JSON.
 
-	senzingSettings, err := buildSenzingEngineConfigurationJSON(ctx, myViper)
+	senzingSettings, err := buildSenzingEngineConfigurationJSON(myViper)
 	if err != nil {
 		return result, err
 	}
 	return settingsparser.New(senzingSettings)
 }
 
-// Get the path to the SQL file used to create the Senzing database schema.
-func getSQLFileDefault() string {
-	var result string
-	ctx := context.Background()
-
-	// Early exit.  Environment variable is set.
-
-	result, isSet := os.LookupEnv(envarSQLFile)
-	if isSet {
-		return result
-	}
-
-	// Find information from SENZING_TOOLS_ENGINE_CONFIGURATION_JSON.
-
-	parsedSenzingEngineConfigurationJSON, err := getParsedEngineConfigurationJSON()
-	if err != nil {
-		return result
+// Parse the JSON object of per-database-URL SQL files.
+func getSQLFileMap(aViper *viper.Viper) (map[string]string, error) {
+	var result map[string]string
+	sqlFileMap := aViper.GetString(OptionSQLFileMap.Arg)
+	if len(sqlFileMap) == 0 {
+		return result, nil
 	}
-	resourcePath, err := parsedSenzingEngineConfigurationJSON.GetResourcePath(ctx)
-	if err != nil {
-		return result
-	}
-	databaseURLs, err := parsedSenzingEngineConfigurationJSON.GetDatabaseURLs(ctx)
+	err := json.Unmarshal([]byte(sqlFileMap), &result)
 	if err != nil {
-		return result
-	}
-	if len(databaseURLs) == 0 {
-		return result
+		return result, fmt.Errorf("invalid %s: %w", OptionSQLFileMap.Arg, err)
 	}
-	databaseURL := databaseURLs[0]
-
-	// Parse database URL to find which type of database is used.
+	return result, err
+}
 
-	parsedURL, err := dbhelper.ParseDatabaseURL(databaseURL)
-	if err != nil {
-		return result
+// Get the variables for SQL file templates from the variables file, then from the individual options.
+func getSQLVariables(aViper *viper.Viper) (map[string]string, error) {
+	result := map[string]string{}
+	sqlVariablesFile := aViper.GetString(OptionSQLVariablesFile.Arg)
+	if len(sqlVariablesFile) > 0 {
+		content, err := os.ReadFile(filepath.Clean(sqlVariablesFile))
+		if err != nil {
+			return result, err
+		}
+		err = json.Unmarshal(content, &result)
+		if err != nil {
+			return result, fmt.Errorf("invalid %s %s: %w", OptionSQLVariablesFile.Arg, sqlVariablesFile, err)
+		}
 	}
-
-	// Based on database type, choose SQL file.
-
-	switch parsedURL.Scheme {
-	case "mssql":
-		result = resourcePath + "/schema/szcore-schema-mssql-create.sql"
-	case "mysql":
-		result = resourcePath + "/schema/szcore-schema-mysql-create.sql"
-	case "oci":
-		result = resourcePath + "/schema/szcore-schema-oracle-create.sql"
-	case "postgresql":
-		result = resourcePath + "/schema/szcore-schema-postgresql-create.sql"
-	case "sqlite3":
-		result = resourcePath + "/schema/szcore-schema-sqlite-create.sql"
+	options := map[string]option.ContextVariable{
+		senzingschema.SQLVariableIndexTablespace: OptionSQLIndexTablespace,
+		senzingschema.SQLVariableSchema:          OptionSQLSchema,
+		senzingschema.SQLVariableTableOptions:    OptionSQLTableOptions,
+		senzingschema.SQLVariableTablespace:      OptionSQLTablespace,
 	}
-
-	return result
+	for name, contextVariable := range options {
+		if value := aViper.GetString(contextVariable.Arg); len(value) > 0 {
+			result[name] = value
+		}
+	}
+	return result, nil
 }
 
 // Since init() is always invoked, define command line parameters.
 func init() {
-	cmdhelper.Init(RootCmd, append(ContextVariables, OptionSQLFile, OptionEngineConfigurationFile))
+	cmdhelper.Init(RootCmd, append(ContextVariables, OptionAdminDatabaseURL, OptionAdminDatabaseURLFile, OptionCreateDatabase, OptionDatabaseCollation, OptionDatabaseEncoding, OptionDatabaseRetries, OptionDatabaseRetryDelayInSeconds, OptionDatabaseSchema, OptionDatabaseTimeoutInSeconds, OptionDeleteUnusedDatasources, OptionDryRun, OptionLockTimeoutInSeconds, OptionReconcileDatasources, OptionRuntimePassword, OptionRuntimePasswordFile, OptionRuntimeUser, OptionSQLFile, OptionSQLFileMap, OptionSQLIndexTablespace, OptionSQLSchema, OptionSQLTableOptions, OptionSQLTablespace, OptionSQLVariablesFile, OptionEngineConfigurationFile))
 }
@@ -0,0 +1,147 @@
+/*
+ */
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/senzing-garage/go-cmdhelping/cmdhelper"
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-cmdhelping/option/optiontype"
+	"github.com/senzing-garage/init-database/senzingschema"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	envarOutputFormat string = "SENZING_TOOLS_OUTPUT_FORMAT"
+)
+
+const (
+	outputFormatJSON string = "json"
+	outputFormatText string = "text"
+)
+
+// ----------------------------------------------------------------------------
+// Context variables
+// ----------------------------------------------------------------------------
+
+var OptionOutputFormat = option.ContextVariable{
+	Arg:     "output-format",
+	Default: option.OsLookupEnvString(envarOutputFormat, outputFormatText),
+	Envar:   envarOutputFormat,
+	Help:    "Format of the report: text or json [%s]",
+	Type:    optiontype.String,
+}
+
+var verifySchemaContextVariables = append(append([]option.ContextVariable{}, ContextVariables...), OptionOutputFormat, OptionSQLFile, OptionSQLFileMap, OptionSQLIndexTablespace, OptionSQLSchema, OptionSQLTableOptions, OptionSQLTablespace, OptionSQLVariablesFile)
+
+// ----------------------------------------------------------------------------
+// Command
+// ----------------------------------------------------------------------------
+
+// verifySchemaCmd represents the verify-schema command
+var verifySchemaCmd = &cobra.Command{
+	Use:   "verify-schema",
+	Short: "Report differences between Senzing databases and their SQL files",
+	Long: `
+Compare the tables, columns, primary keys and indexes in each Senzing database
+with those declared by the SQL file that would be used to create the database.
+Missing, extra and mismatched objects are reported.  The databases are not changed.
+The command exits with an error if any difference is found.
+`,
+	PreRun: func(cobraCommand *cobra.Command, args []string) {
+		cmdhelper.PreRun(cobraCommand, args, Use, verifySchemaContextVariables)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_ = args
+		return verifySchemaAction(context.Background(), viper.GetViper(), cmd.OutOrStdout())
+	},
+	SilenceUsage: true,
+}
+
+func init() {
+	RootCmd.AddCommand(verifySchemaCmd)
+	cmdhelper.Init(verifySchemaCmd, verifySchemaContextVariables)
+}
+
+func verifySchemaAction(ctx context.Context, aViper *viper.Viper, writer io.Writer) error {
+	outputFormat := aViper.GetString(OptionOutputFormat.Arg)
+	if outputFormat != outputFormatText && outputFormat != outputFormatJSON {
+		return fmt.Errorf("invalid %s: %s", OptionOutputFormat.Arg, outputFormat)
+	}
+	senzingSettings, err := getSenzingSettings(ctx, aViper)
+	if err != nil {
+		return err
+	}
+	sqlFileMap, err := getSQLFileMap(aViper)
+	if err != nil {
+		return err
+	}
+	sqlVariables, err := getSQLVariables(aViper)
+	if err != nil {
+		return err
+	}
+	senzingSchema := &senzingschema.BasicSenzingSchema{
+		SenzingSettings: senzingSettings,
+		SQLFile:         aViper.GetString(OptionSQLFile.Arg),
+		SQLFileMap:      sqlFileMap,
+		SQLVariables:    sqlVariables,
+	}
+	err = senzingSchema.SetLogLevel(ctx, aViper.GetString(option.LogLevel.Arg))
+	if err != nil {
+		return err
+	}
+	differences, err := senzingSchema.VerifySchema(ctx)
+	if err != nil {
+		return err
+	}
+	err = writeSchemaDifferences(writer, outputFormat, differences)
+	if err != nil {
+		return err
+	}
+	if len(differences) > 0 {
+		return fmt.Errorf("schema drift found: %d differences", len(differences))
+	}
+	return nil
+}
+
+// Write a schema drift report as text or JSON.
+func writeSchemaDifferences(writer io.Writer, outputFormat string, differences []senzingschema.SchemaDifference) error {
+	var err error
+	if outputFormat == outputFormatJSON {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "    ")
+		return encoder.Encode(differences)
+	}
+	if len(differences) == 0 {
+		_, err = fmt.Fprintln(writer, "No schema drift found.")
+		return err
+	}
+	for _, difference := range differences {
+		_, err = fmt.Fprintf(writer, "%s: %s %s is %s", difference.Database, difference.Kind, difference.Object, difference.Problem)
+		if err != nil {
+			return err
+		}
+		if len(difference.Expected) > 0 {
+			_, err = fmt.Fprintf(writer, "; expected: %s", difference.Expected)
+			if err != nil {
+				return err
+			}
+		}
+		if len(difference.Actual) > 0 {
+			_, err = fmt.Fprintf(writer, "; actual: %s", difference.Actual)
+			if err != nil {
+				return err
+			}
+		}
+		_, err = fmt.Fprintln(writer)
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,20 @@
+/*
+Package initerror classifies the errors returned by init-database's packages.
+
+Each error returned by the initializer, senzingconfig and senzingschema packages for a known
+class of failure wraps one of the Err* sentinels and an *Error carrying the identifier of the
+log message describing the failure, in the "senzing-650xxxxx" format.  The underlying error
+remains available to errors.Is and errors.As.
+
+Example:
+
+	err := anInitializer.Initialize(ctx)
+	if errors.Is(err, initerror.ErrConnect) {
+		// Retry later.
+	}
+	var initError *initerror.Error
+	if errors.As(err, &initError) {
+		fmt.Println(initError.MessageID)
+	}
+*/
+package initerror
@@ -0,0 +1,41 @@
+package initerror
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestNew(test *testing.T) {
+	err := New(ErrFileMissing, 6503, 1103, fmt.Errorf("open /tmp/missing.sql: %w", fs.ErrNotExist))
+	require.EqualError(test, err, "open /tmp/missing.sql: file does not exist")
+	require.ErrorIs(test, err, ErrFileMissing)
+	require.ErrorIs(test, err, fs.ErrNotExist)
+	require.NotErrorIs(test, err, ErrConnect)
+	var initError *Error
+	require.ErrorAs(test, err, &initError)
+	require.Equal(test, "senzing-65031103", initError.MessageID)
+}
+
+func TestNew_classified(test *testing.T) {
+	inner := New(ErrConnect, 6503, 1102, errors.New("connection refused"))
+	err := New(ErrSchemaDDL, 6501, 1014, inner)
+	require.Same(test, inner, err)
+	require.ErrorIs(test, err, ErrConnect)
+	require.NotErrorIs(test, err, ErrSchemaDDL)
+}
+
+func TestNew_nil(test *testing.T) {
+	require.NoError(test, New(ErrSettings, 6502, 1020, nil))
+}
+
+func TestMessageID(test *testing.T) {
+	require.Equal(test, "senzing-65010075", MessageID(6501, 75))
+}
@@ -0,0 +1,81 @@
+package initerror
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Error is a classified failure and the identifier of the message describing it.
+type Error struct {
+	Class     error  // One of the Err* sentinels.
+	MessageID string // Example: senzing-65031102
+	Err       error  // The underlying error.
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Classes of failure.
+var (
+	ErrConfigCreate          = errors.New("cannot create Senzing configuration")
+	ErrConfigExists          = errors.New("Senzing configuration already exists")
+	ErrConfigNotFound        = errors.New("Senzing configuration does not exist")
+	ErrConfigParse           = errors.New("cannot parse Senzing configuration")
+	ErrConfigSave            = errors.New("cannot save Senzing configuration")
+	ErrConnect               = errors.New("cannot connect to database")
+	ErrFileMissing           = errors.New("file does not exist")
+	ErrInvalidLogLevel       = errors.New("invalid log level")
+	ErrSchemaDDL             = errors.New("cannot change database schema")
+	ErrSchemaQuery           = errors.New("cannot inspect database schema")
+	ErrSchemaVersion         = errors.New("unsupported database schema version")
+	ErrSettings              = errors.New("invalid Senzing settings")
+	ErrSQLFile               = errors.New("cannot read SQL file")
+	ErrUnknownDatabaseScheme = errors.New("unknown database scheme")
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+New classifies an error and identifies the message describing it.
+An error that is nil, or already classified by a more specific call, is returned unchanged.
+*/
+func New(class error, componentID int, messageNumber int, err error) error {
+	if err == nil {
+		return nil
+	}
+	var classified *Error
+	if errors.As(err, &classified) {
+		return err
+	}
+	return &Error{
+		Class:     class,
+		MessageID: MessageID(componentID, messageNumber),
+		Err:       err,
+	}
+}
+
+// MessageID returns the identifier of a message, as it appears in logs.
+func MessageID(componentID int, messageNumber int) string {
+	return fmt.Sprintf("senzing-%04d%04d", componentID, messageNumber)
+}
+
+// ----------------------------------------------------------------------------
+// Error methods
+// ----------------------------------------------------------------------------
+
+// Error returns the underlying error's message.
+func (classified *Error) Error() string {
+	return classified.Err.Error()
+}
+
+// Unwrap lets errors.Is and errors.As match both the class and the underlying error.
+func (classified *Error) Unwrap() []error {
+	return []error{classified.Class, classified.Err}
+}
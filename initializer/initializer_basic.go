@@ -2,13 +2,18 @@ package initializer
 
 import (
 	"context"
-	"encoding/json"
+	"database/sql"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/senzing-garage/go-databasing/connector"
 	"github.com/senzing-garage/go-databasing/dbhelper"
 	"github.com/senzing-garage/go-helpers/settingsparser"
 	"github.com/senzing-garage/go-logging/logging"
@@ -16,6 +21,8 @@ import (
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/go-observing/observerpb"
 	"github.com/senzing-garage/go-observing/subject"
+	"github.com/senzing-garage/init-database/initerror"
+	"github.com/senzing-garage/init-database/redact"
 	"github.com/senzing-garage/init-database/senzingconfig"
 	"github.com/senzing-garage/init-database/senzingschema"
 	"google.golang.org/grpc"
@@ -28,20 +35,40 @@ import (
 
 // BasicInitializer is the default implementation of the Initializer interface.
 type BasicInitializer struct {
-	DataSources           []string `json:"dataSources,omitempty"`
-	ObserverOrigin        string   `json:"observerOrigin,omitempty"`
-	ObserverURL           string   `json:"observerUrl,omitempty"`
-	SenzingInstanceName   string   `json:"senzingInstanceName,omitempty"`
-	SenzingLogLevel       string   `json:"senzingLogLevel,omitempty"`
-	SenzingSettings       string   `json:"senzingSettings,omitempty"`
-	SenzingSettingsFile   string   `json:"senzingSettingsFile,omitempty"`
-	SenzingVerboseLogging int64    `json:"senzingVerboseLogging,omitempty"`
-	SQLFile               string   `json:"sqlFile,omitempty"`
+	AdminDatabaseURL        string            `json:"adminDatabaseUrl,omitempty"`
+	CreateDatabase          bool              `json:"createDatabase,omitempty"`
+	DatabaseCollation       string            `json:"databaseCollation,omitempty"`
+	DatabaseEncoding        string            `json:"databaseEncoding,omitempty"`
+	DatabaseRetries         int               `json:"databaseRetries,omitempty"`
+	DatabaseRetryDelay      time.Duration     `json:"databaseRetryDelay,omitempty"`
+	DatabaseSchema          string            `json:"databaseSchema,omitempty"`
+	DatabaseTimeout         time.Duration     `json:"databaseTimeout,omitempty"`
+	DataSources             []string          `json:"dataSources,omitempty"`
+	DeleteUnusedDataSources bool              `json:"deleteUnusedDataSources,omitempty"`
+	DryRun                  bool              `json:"dryRun,omitempty"`
+	DryRunOutput            io.Writer         `json:"-"`
+	LockTimeout             time.Duration     `json:"lockTimeout,omitempty"`
+	MigrationDirectory      string            `json:"migrationDirectory,omitempty"`
+	ObserverOrigin          string            `json:"observerOrigin,omitempty"`
+	ObserverURL             string            `json:"observerUrl,omitempty"`
+	ReconcileDataSources    bool              `json:"reconcileDataSources,omitempty"`
+	RuntimePassword         string            `json:"-"`
+	RuntimeUser             string            `json:"runtimeUser,omitempty"`
+	SenzingInstanceName     string            `json:"senzingInstanceName,omitempty"`
+	SenzingLogLevel         string            `json:"senzingLogLevel,omitempty"`
+	SenzingSettings         string            `json:"senzingSettings,omitempty"`
+	SenzingSettingsFile     string            `json:"senzingSettingsFile,omitempty"`
+	SenzingVerboseLogging   int64             `json:"senzingVerboseLogging,omitempty"`
+	SQLFile                 string            `json:"sqlFile,omitempty"`
+	SQLFileMap              map[string]string `json:"sqlFileMap,omitempty"`
+	SQLVariables            map[string]string `json:"sqlVariables,omitempty"`
+	TargetSchemaVersion     string            `json:"targetSchemaVersion,omitempty"`
 
 	logger                 logging.Logging
+	loggerErr              error
 	observers              subject.Subject
 	senzingConfigSingleton senzingconfig.SenzingConfig
-	senzingSchemaSingleton senzingschema.SenzingSchema
+	senzingSchemaSingleton *senzingschema.BasicSenzingSchema
 }
 
 // ----------------------------------------------------------------------------
@@ -56,6 +83,30 @@ var traceOptions = []interface{}{
 	&logging.OptionCallerSkip{Value: 5},
 }
 
+// Per database scheme, the database that always exists on a server.
+var maintenanceDatabases = map[string]string{
+	"mssql":      "master",
+	"mysql":      "",
+	"postgresql": "postgres",
+}
+
+// Per database scheme, a query counting databases with a given name.
+var databaseExistsSQL = map[string]string{
+	"mssql":      "SELECT COUNT(*) FROM sys.databases WHERE name = @p1",
+	"mysql":      "SELECT COUNT(*) FROM information_schema.schemata WHERE schema_name = ?",
+	"postgresql": "SELECT COUNT(*) FROM pg_database WHERE datname = $1",
+}
+
+// Encoding and collation names are inserted into SQL, so only plain names are accepted.
+var regexpCharacterSetName = regexp.MustCompile(`^[A-Za-z0-9_.@-]+$`)
+
+// Defaults and limits for waiting on databases.
+var (
+	defaultDatabaseRetryDelay = time.Second
+	defaultDatabaseTimeout    = 10 * time.Second
+	maxDatabaseRetryDelay     = time.Minute
+)
+
 // ----------------------------------------------------------------------------
 // Interface methods
 // ----------------------------------------------------------------------------
@@ -70,7 +121,7 @@ Input
 func (initializer *BasicInitializer) Initialize(ctx context.Context) error {
 	var err error
 	debugMessageNumber := 0
-	traceExitMessageNumber := 19
+	traceExitMessageNumber := 29
 
 	// Initialize logging.
 
@@ -105,56 +156,68 @@ func (initializer *BasicInitializer) Initialize(ctx context.Context) error {
 
 		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
 
-		asJSON, err := json.Marshal(initializer)
+		asJSON, err := redact.Marshal(initializer)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 11, 1011
 			return err
 		}
-		initializer.log(1000, initializer, string(asJSON))
+		initializer.log(1000, string(asJSON))
+	}
+
+	// A SenzingLogLevel the logger rejected is an error.
+
+	if initializer.loggerErr != nil {
+		traceExitMessageNumber, debugMessageNumber = 25, 1025
+		return wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, initializer.loggerErr)
 	}
 
 	// Initialize observing.
 
-	var anObserver observer.Observer
-	if len(initializer.ObserverURL) > 0 {
-		parsedURL, err := url.Parse(initializer.ObserverURL)
+	anObserver, err := initializer.createObserver(ctx)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 18, 1018
+		return err
+	}
+
+	// Verify database files exist.
+
+	if len(initializer.SQLFile) > 0 {
+		_, err = os.Stat(initializer.SQLFile)
 		if err != nil {
-			return err
-		}
-		switch parsedURL.Scheme {
-		case "grpc":
-			anObserver, err = initializer.createGrpcObserver(ctx, *parsedURL)
-			if err != nil {
-				traceExitMessageNumber, debugMessageNumber = 18, 1018
-				return err
-			}
-		default:
+			initializer.log(3001, initializer.SQLFile)
+			traceExitMessageNumber, debugMessageNumber = 21, 1075
+			return wrapError(initerror.ErrFileMissing, debugMessageNumber, err)
 		}
-		err = initializer.registerObserverLocal(ctx, anObserver)
+	}
+	for _, sqlFile := range initializer.SQLFileMap {
+		_, err = os.Stat(sqlFile)
 		if err != nil {
-			traceExitMessageNumber, debugMessageNumber = 17, 1017
-			return err
+			initializer.log(3001, sqlFile)
+			traceExitMessageNumber, debugMessageNumber = 21, 1075
+			return wrapError(initerror.ErrFileMissing, debugMessageNumber, err)
 		}
+	}
 
-		// Notify observers.
+	// Wait until databases accept connections.
 
-		go func() {
-			details := map[string]string{
-				"observerID": anObserver.GetObserverID(ctx),
-			}
-			notifier.Notify(ctx, initializer.observers, initializer.ObserverOrigin, ComponentID, 8001, err, details)
-		}()
+	if initializer.DatabaseRetries > 0 || initializer.DatabaseTimeout > 0 {
+		err = initializer.waitForDatabases(ctx)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 22, 1022
+			return wrapError(initerror.ErrConnect, debugMessageNumber, err)
+		}
 	}
 
-	// Verify database file exists.
+	// Keep other init-database processes out until initialization is complete.
 
-	if len(initializer.SQLFile) > 0 {
-		_, err = os.Stat(initializer.SQLFile)
+	if !initializer.DryRun {
+		var locks []*initializationLock
+		locks, err = initializer.acquireInitializationLock(ctx)
 		if err != nil {
-			initializer.log(3001, initializer.SQLFile)
-			traceExitMessageNumber, debugMessageNumber = 21, 1075
-			return err
+			traceExitMessageNumber, debugMessageNumber = 24, 1024
+			return wrapError(initerror.ErrConnect, debugMessageNumber, err)
 		}
+		defer initializer.releaseInitializationLock(ctx, locks)
 	}
 
 	// Perform initialization for specific databases.
@@ -162,7 +225,7 @@ func (initializer *BasicInitializer) Initialize(ctx context.Context) error {
 	err = initializer.InitializeSpecificDatabase(ctx)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 12, 1012
-		return err
+		return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
 	}
 
 	// Create schema in database.
@@ -171,7 +234,7 @@ func (initializer *BasicInitializer) Initialize(ctx context.Context) error {
 	err = senzingSchema.SetLogLevel(ctx, logLevel)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 13, 1013
-		return err
+		return wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, err)
 	}
 	err = initializer.registerObserverSenzingSchema(ctx, anObserver)
 	if err != nil {
@@ -181,7 +244,23 @@ func (initializer *BasicInitializer) Initialize(ctx context.Context) error {
 	err = senzingSchema.InitializeSenzing(ctx)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 14, 1014
-		return err
+		return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
+	}
+
+	// When DDL was run as an administrator, give the runtime role access to the tables.
+
+	if len(initializer.AdminDatabaseURL) > 0 {
+		var roleName, password string
+		roleName, password, err = initializer.getRuntimeRole(ctx)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 23, 1023
+			return wrapError(initerror.ErrSettings, debugMessageNumber, err)
+		}
+		err = senzingSchema.GrantRuntimeAccess(ctx, roleName, password)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 23, 1023
+			return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
+		}
 	}
 
 	// Create initial Senzing configuration.
@@ -190,7 +269,7 @@ func (initializer *BasicInitializer) Initialize(ctx context.Context) error {
 	err = senzingConfig.SetLogLevel(ctx, logLevel)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 15, 1015
-		return err
+		return wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, err)
 	}
 	err = initializer.registerObserverSenzingConfig(ctx, anObserver)
 	if err != nil {
@@ -200,7 +279,7 @@ func (initializer *BasicInitializer) Initialize(ctx context.Context) error {
 	err = senzingConfig.InitializeSenzing(ctx)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 16, 1016
-		return err
+		return wrapError(initerror.ErrConfigCreate, debugMessageNumber, err)
 	}
 
 	// Notify observers.
@@ -249,12 +328,19 @@ func (initializer *BasicInitializer) InitializeSpecificDatabase(ctx context.Cont
 
 		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
 
-		asJSON, err := json.Marshal(initializer)
+		asJSON, err := redact.Marshal(initializer)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 41, 1041
 			return err
 		}
-		initializer.log(1001, initializer, string(asJSON))
+		initializer.log(1001, string(asJSON))
+	}
+
+	// A SenzingLogLevel the logger rejected is an error.
+
+	if initializer.loggerErr != nil {
+		traceExitMessageNumber, debugMessageNumber = 47, 1047
+		return wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, initializer.loggerErr)
 	}
 
 	// Pull values out of SenzingEngineConfigurationJson.
@@ -262,12 +348,12 @@ func (initializer *BasicInitializer) InitializeSpecificDatabase(ctx context.Cont
 	parser, err := settingsparser.New(initializer.SenzingSettings)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 42, 1042
-		return err
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
 	}
-	databaseURLs, err = parser.GetDatabaseURLs(ctx)
+	databaseURLs, err = initializer.getDatabaseURLs(ctx, parser)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 43, 1043
-		return err
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
 	}
 
 	// Process each database.
@@ -279,7 +365,7 @@ func (initializer *BasicInitializer) InitializeSpecificDatabase(ctx context.Cont
 		parsedURL, err := dbhelper.ParseDatabaseURL(databaseURL)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 44, 1044
-			return err
+			return wrapError(initerror.ErrSettings, debugMessageNumber, err)
 		}
 
 		// Special handling for each database type.
@@ -289,7 +375,15 @@ func (initializer *BasicInitializer) InitializeSpecificDatabase(ctx context.Cont
 			err = initializer.initializeSpecificDatabaseSqlite(ctx, parsedURL)
 			if err != nil {
 				traceExitMessageNumber, debugMessageNumber = 45, 1045
-				return err
+				return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
+			}
+		case "mssql", "mysql", "postgresql":
+			if initializer.CreateDatabase {
+				err = initializer.initializeSpecificDatabaseServer(ctx, parsedURL)
+				if err != nil {
+					traceExitMessageNumber, debugMessageNumber = 46, 1046
+					return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
+				}
 			}
 		default:
 		}
@@ -297,6 +391,113 @@ func (initializer *BasicInitializer) InitializeSpecificDatabase(ctx context.Cont
 	return err
 }
 
+/*
+The Migrate method brings the Senzing database schema of existing databases up to a newer version
+by applying migration files.  Essentially it calls senzingSchema.Migrate(ctx).
+
+Input
+  - ctx: A context to control lifecycle.
+*/
+func (initializer *BasicInitializer) Migrate(ctx context.Context) error {
+	var err error
+	debugMessageNumber := 0
+	traceExitMessageNumber := 99
+
+	// Initialize logging.
+
+	logLevel := initializer.SenzingLogLevel
+	if logLevel == "" {
+		logLevel = "INFO"
+	}
+	err = initializer.SetLogLevel(ctx, logLevel)
+	if err != nil {
+		return err
+	}
+
+	// Prolog.
+
+	if initializer.getLogger().IsDebug() {
+
+		// If DEBUG, log error exit.
+
+		defer func() {
+			if debugMessageNumber > 0 {
+				initializer.debug(debugMessageNumber, err)
+			}
+		}()
+
+		// If TRACE, Log on entry/exit.
+
+		if initializer.getLogger().IsTrace() {
+			entryTime := time.Now()
+			initializer.traceEntry(90)
+			defer func() { initializer.traceExit(traceExitMessageNumber, err, time.Since(entryTime)) }()
+		}
+
+		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
+
+		asJSON, err := redact.Marshal(initializer)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 91, 1091
+			return err
+		}
+		initializer.log(1006, string(asJSON))
+	}
+
+	// A SenzingLogLevel the logger rejected is an error.
+
+	if initializer.loggerErr != nil {
+		traceExitMessageNumber, debugMessageNumber = 97, 1097
+		return wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, initializer.loggerErr)
+	}
+
+	// Initialize observing.
+
+	anObserver, err := initializer.createObserver(ctx)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 92, 1092
+		return err
+	}
+
+	// Keep other init-database processes out until migration is complete.
+
+	locks, err := initializer.acquireInitializationLock(ctx)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 96, 1096
+		return wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+	defer initializer.releaseInitializationLock(ctx, locks)
+
+	// Migrate schema in database.
+
+	senzingSchema := initializer.getSenzingSchema()
+	err = senzingSchema.SetLogLevel(ctx, logLevel)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 93, 1093
+		return wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, err)
+	}
+	err = initializer.registerObserverSenzingSchema(ctx, anObserver)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 94, 1094
+		return err
+	}
+	err = senzingSchema.Migrate(ctx)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 95, 1095
+		return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
+	}
+
+	// Notify observers.
+
+	if initializer.observers != nil {
+		go func() {
+			details := map[string]string{}
+			notifier.Notify(ctx, initializer.observers, initializer.ObserverOrigin, ComponentID, 8011, err, details)
+		}()
+	}
+	return err
+}
+
 /*
 The RegisterObserver method adds the observer to the list of observers notified.
 
@@ -337,12 +538,12 @@ func (initializer *BasicInitializer) RegisterObserver(ctx context.Context, obser
 
 		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
 
-		asJSON, err := json.Marshal(initializer)
+		asJSON, err := redact.Marshal(initializer)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 51, 1051
 			return err
 		}
-		initializer.log(1002, initializer, string(asJSON))
+		initializer.log(1002, string(asJSON))
 	}
 
 	// Create empty list of observers.
@@ -414,19 +615,19 @@ func (initializer *BasicInitializer) SetLogLevel(ctx context.Context, logLevelNa
 
 		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
 
-		asJSON, err := json.Marshal(initializer)
+		asJSON, err := redact.Marshal(initializer)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 61, 1061
 			return err
 		}
-		initializer.log(1003, initializer, string(asJSON))
+		initializer.log(1003, string(asJSON))
 	}
 
 	// Verify value of logLevelName.
 
 	if !logging.IsValidLogLevelName(logLevelName) {
 		traceExitMessageNumber, debugMessageNumber = 62, 1062
-		return fmt.Errorf("invalid error level: %s", logLevelName)
+		return wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, fmt.Errorf("invalid error level: %s", logLevelName))
 	}
 
 	// Set initializer log level.
@@ -434,8 +635,9 @@ func (initializer *BasicInitializer) SetLogLevel(ctx context.Context, logLevelNa
 	err = initializer.getLogger().SetLogLevel(logLevelName)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 63, 1063
-		return err
+		return wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, err)
 	}
+	initializer.loggerErr = nil // A valid log level replaces a rejected SenzingLogLevel.
 
 	// Set log level for dependent services.
 
@@ -501,12 +703,12 @@ func (initializer *BasicInitializer) SetObserverOrigin(ctx context.Context, orig
 
 		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
 
-		asJSON, err := json.Marshal(initializer)
+		asJSON, err := redact.Marshal(initializer)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 81, 1081
 			return
 		}
-		initializer.log(1004, initializer, string(asJSON))
+		initializer.log(1004, string(asJSON))
 	}
 
 	// Set "origin".
@@ -572,12 +774,12 @@ func (initializer *BasicInitializer) UnregisterObserver(ctx context.Context, obs
 
 		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
 
-		asJSON, err := json.Marshal(initializer)
+		asJSON, err := redact.Marshal(initializer)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 71, 1071
 			return err
 		}
-		initializer.log(1005, initializer, string(asJSON))
+		initializer.log(1005, string(asJSON))
 	}
 
 	// Unregister observers in dependencies.
@@ -626,8 +828,8 @@ func (initializer *BasicInitializer) UnregisterObserver(ctx context.Context, obs
 // --- Logging ----------------------------------------------------------------
 
 // Get the Logger singleton.
+// If SenzingLogLevel is rejected, loggerErr records why and the logger uses the default log level.
 func (initializer *BasicInitializer) getLogger() logging.Logging {
-	var err error
 	if initializer.logger == nil {
 		options := []interface{}{
 			logging.OptionCallerSkip{Value: 4},
@@ -635,9 +837,9 @@ func (initializer *BasicInitializer) getLogger() logging.Logging {
 		if len(initializer.SenzingLogLevel) > 0 {
 			options = append(options, logging.OptionLogLevel{Value: initializer.SenzingLogLevel})
 		}
-		initializer.logger, err = logging.NewSenzingLogger(ComponentID, IDMessages, options...)
-		if err != nil {
-			panic(err)
+		initializer.logger, initializer.loggerErr = logging.NewSenzingLogger(ComponentID, IDMessages, options...)
+		if initializer.loggerErr != nil {
+			initializer.logger, _ = logging.NewSenzingLogger(ComponentID, IDMessages, options[0]) // Only a log level can be rejected.
 		}
 	}
 	return initializer.logger
@@ -666,32 +868,40 @@ func (initializer *BasicInitializer) traceExit(messageNumber int, details ...int
 	initializer.getLogger().Log(messageNumber, details...)
 }
 
-// --- Observing --------------------------------------------------------------
+// --- Errors -----------------------------------------------------------------
 
-func (initializer *BasicInitializer) createGrpcObserver(ctx context.Context, parsedURL url.URL) (observer.Observer, error) {
-	_ = ctx
-	var err error
+// Classify an error, identifying it by the debug message describing it.
+func wrapError(class error, messageNumber int, err error) error {
+	return initerror.New(class, ComponentID, messageNumber, err)
+}
 
-	var result observer.Observer
+// --- Observing --------------------------------------------------------------
 
-	port := DefaultGrpcObserverPort
-	if len(parsedURL.Port()) > 0 {
-		port = parsedURL.Port()
+// Create the observer named by ObserverURL, if any, and register it locally.
+func (initializer *BasicInitializer) createObserver(ctx context.Context) (observer.Observer, error) {
+	var err error
+	var anObserver observer.Observer
+	if len(initializer.ObserverURL) == 0 {
+		return anObserver, err
 	}
-	target := fmt.Sprintf("%s:%s", parsedURL.Hostname(), port)
-
-	// TODO: Allow specification of options from ObserverUrl/parsedUrl
-	grpcOptions := grpc.WithTransportCredentials(insecure.NewCredentials())
-
-	grpcConnection, err := grpc.NewClient(target, grpcOptions)
+	anObserver, err = NewObserver(ctx, initializer.ObserverURL)
 	if err != nil {
-		return result, err
+		return anObserver, err
 	}
-	result = &observer.GrpcObserver{
-		GrpcClient: observerpb.NewObserverClient(grpcConnection),
-		ID:         "init-database",
+	err = initializer.registerObserverLocal(ctx, anObserver)
+	if err != nil {
+		return anObserver, err
 	}
-	return result, err
+
+	// Notify observers.
+
+	go func() {
+		details := map[string]string{
+			"observerID": anObserver.GetObserverID(ctx),
+		}
+		notifier.Notify(ctx, initializer.observers, initializer.ObserverOrigin, ComponentID, 8001, err, details)
+	}()
+	return anObserver, err
 }
 
 func (initializer *BasicInitializer) registerObserverLocal(ctx context.Context, observer observer.Observer) error {
@@ -711,26 +921,200 @@ func (initializer *BasicInitializer) registerObserverSenzingSchema(ctx context.C
 	return initializer.getSenzingSchema().RegisterObserver(ctx, observer)
 }
 
+// --- Dry run ----------------------------------------------------------------
+
+// Where dry run output is written.
+func (initializer *BasicInitializer) getDryRunOutput() io.Writer {
+	if initializer.DryRunOutput == nil {
+		return os.Stdout
+	}
+	return initializer.DryRunOutput
+}
+
+// --- Database readiness -----------------------------------------------------
+
+// Wait for each database, except SQLite, to accept connections.
+func (initializer *BasicInitializer) waitForDatabases(ctx context.Context) error {
+	parser, err := settingsparser.New(initializer.SenzingSettings)
+	if err != nil {
+		return err
+	}
+	databaseURLs, err := initializer.getDatabaseURLs(ctx, parser)
+	if err != nil {
+		return err
+	}
+	for _, databaseURL := range databaseURLs {
+		err = initializer.waitForDatabase(ctx, databaseURL)
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// Probe a database until it accepts connections, retrying with exponential backoff.
+func (initializer *BasicInitializer) waitForDatabase(ctx context.Context, databaseURL string) error {
+	var err error
+
+	// Prolog.
+
+	debugMessageNumber := 0
+	traceExitMessageNumber := 119
+	if initializer.getLogger().IsDebug() {
+
+		// If DEBUG, log error exit.
+
+		defer func() {
+			if debugMessageNumber > 0 {
+				initializer.debug(debugMessageNumber, err)
+			}
+		}()
+
+		// If TRACE, Log on entry/exit.
+
+		if initializer.getLogger().IsTrace() {
+			entryTime := time.Now()
+			initializer.traceEntry(110)
+			defer func() { initializer.traceExit(traceExitMessageNumber, err, time.Since(entryTime)) }()
+		}
+	}
+
+	parsedURL, err := dbhelper.ParseDatabaseURL(databaseURL)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 111, 1111
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
+	}
+	if parsedURL.Scheme == "sqlite3" {
+		traceExitMessageNumber, debugMessageNumber = 112, 0 // debugMessageNumber=0 because it's not an error.
+		return err                                          // A SQLite file needs no server.
+	}
+
+	// A database that is about to be created cannot be reached yet, so wait for its server instead.
+
+	probeURL := databaseURL
+	if _, ok := maintenanceDatabases[parsedURL.Scheme]; ok && initializer.CreateDatabase {
+		probeURL = maintenanceDatabaseURL(parsedURL).String()
+	}
+
+	timeout := initializer.DatabaseTimeout
+	if timeout <= 0 {
+		timeout = defaultDatabaseTimeout
+	}
+	delay := initializer.DatabaseRetryDelay
+	if delay <= 0 {
+		delay = defaultDatabaseRetryDelay
+	}
+	attempts := initializer.DatabaseRetries + 1
+	for attempt := 1; attempt <= attempts; attempt++ {
+		err = pingDatabase(ctx, probeURL, timeout)
+		initializer.notifyDatabaseAttempt(ctx, parsedURL.Redacted(), attempt, attempts, err)
+		if err == nil {
+			initializer.log(2002, parsedURL.Redacted(), attempt)
+			return err
+		}
+		if attempt == attempts {
+			break
+		}
+		initializer.log(3002, parsedURL.Redacted(), attempt, attempts, delay, err)
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			traceExitMessageNumber, debugMessageNumber = 113, 1113
+			return wrapError(initerror.ErrConnect, debugMessageNumber, err)
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxDatabaseRetryDelay)
+	}
+	initializer.log(4001, parsedURL.Redacted(), attempts, err)
+	err = fmt.Errorf("database %s not ready after %d attempts: %w", parsedURL.Redacted(), attempts, err)
+	traceExitMessageNumber, debugMessageNumber = 114, 1114
+	return wrapError(initerror.ErrConnect, debugMessageNumber, err)
+}
+
+func (initializer *BasicInitializer) notifyDatabaseAttempt(ctx context.Context, redactedURL string, attempt int, attempts int, err error) {
+	if initializer.observers != nil {
+		go func() {
+			details := map[string]string{
+				"attempt":     strconv.Itoa(attempt),
+				"attempts":    strconv.Itoa(attempts),
+				"databaseUrl": redactedURL,
+				"ready":       strconv.FormatBool(err == nil),
+			}
+			notifier.Notify(ctx, initializer.observers, initializer.ObserverOrigin, ComponentID, 8012, err, details)
+		}()
+	}
+}
+
+// --- Runtime role -----------------------------------------------------------
+
+// The database URLs to prepare.  An AdminDatabaseURL replaces the single database URL in the Senzing settings.
+func (initializer *BasicInitializer) getDatabaseURLs(ctx context.Context, parser settingsparser.SettingsParser) ([]string, error) {
+	databaseURLs, err := parser.GetDatabaseURLs(ctx)
+	if err != nil || len(initializer.AdminDatabaseURL) == 0 {
+		return databaseURLs, err
+	}
+	if len(databaseURLs) != 1 {
+		return databaseURLs, fmt.Errorf("an admin database URL requires exactly one database in the Senzing settings; found %d", len(databaseURLs))
+	}
+	return []string{initializer.AdminDatabaseURL}, err
+}
+
+// The role Senzing runs as: RuntimeUser, or else the user in the Senzing settings' database URL.
+func (initializer *BasicInitializer) getRuntimeRole(ctx context.Context) (string, string, error) {
+	if len(initializer.RuntimeUser) > 0 {
+		return initializer.RuntimeUser, initializer.RuntimePassword, nil
+	}
+	parser, err := settingsparser.New(initializer.SenzingSettings)
+	if err != nil {
+		return "", "", err
+	}
+	databaseURLs, err := parser.GetDatabaseURLs(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	if len(databaseURLs) != 1 {
+		return "", "", fmt.Errorf("cannot determine the runtime user from %d database URLs", len(databaseURLs))
+	}
+	parsedURL, err := dbhelper.ParseDatabaseURL(databaseURLs[0])
+	if err != nil {
+		return "", "", err
+	}
+	password, _ := parsedURL.User.Password()
+	return parsedURL.User.Username(), password, err
+}
+
 // --- Dependent services -----------------------------------------------------
 
 func (initializer *BasicInitializer) getSenzingConfig() senzingconfig.SenzingConfig {
 	if initializer.senzingConfigSingleton == nil {
 		initializer.senzingConfigSingleton = &senzingconfig.BasicSenzingConfig{
-			DataSources:           initializer.DataSources,
-			SenzingSettingsFile:   initializer.SenzingSettingsFile,
-			SenzingSettings:       initializer.SenzingSettings,
-			SenzingInstanceName:   initializer.SenzingInstanceName,
-			SenzingVerboseLogging: initializer.SenzingVerboseLogging,
+			DataSources:             initializer.DataSources,
+			DeleteUnusedDataSources: initializer.DeleteUnusedDataSources,
+			DryRun:                  initializer.DryRun,
+			DryRunOutput:            initializer.DryRunOutput,
+			ReconcileDataSources:    initializer.ReconcileDataSources,
+			SenzingSettingsFile:     initializer.SenzingSettingsFile,
+			SenzingSettings:         initializer.SenzingSettings,
+			SenzingInstanceName:     initializer.SenzingInstanceName,
+			SenzingVerboseLogging:   initializer.SenzingVerboseLogging,
 		}
 	}
 	return initializer.senzingConfigSingleton
 }
 
-func (initializer *BasicInitializer) getSenzingSchema() senzingschema.SenzingSchema {
+func (initializer *BasicInitializer) getSenzingSchema() *senzingschema.BasicSenzingSchema {
 	if initializer.senzingSchemaSingleton == nil {
 		initializer.senzingSchemaSingleton = &senzingschema.BasicSenzingSchema{
-			SenzingSettings: initializer.SenzingSettings,
-			SQLFile:         initializer.SQLFile,
+			AdminDatabaseURL:    initializer.AdminDatabaseURL,
+			DatabaseSchema:      initializer.DatabaseSchema,
+			DryRun:              initializer.DryRun,
+			DryRunOutput:        initializer.DryRunOutput,
+			MigrationDirectory:  initializer.MigrationDirectory,
+			SenzingSettings:     initializer.SenzingSettings,
+			SQLFile:             initializer.SQLFile,
+			SQLFileMap:          initializer.SQLFileMap,
+			SQLVariables:        initializer.SQLVariables,
+			TargetSchemaVersion: initializer.TargetSchemaVersion,
 		}
 	}
 	return initializer.senzingSchemaSingleton
@@ -738,6 +1122,108 @@ func (initializer *BasicInitializer) getSenzingSchema() senzingschema.SenzingSch
 
 // --- Specific database processing -------------------------------------------
 
+// Create the database named in a PostgreSQL, MySQL or MS SQL URL, if it does not exist.
+func (initializer *BasicInitializer) initializeSpecificDatabaseServer(ctx context.Context, parsedURL *url.URL) error {
+	var err error
+	redactedURL := parsedURL.Redacted()
+
+	// Prolog.
+
+	debugMessageNumber := 0
+	traceExitMessageNumber := 129
+	if initializer.getLogger().IsDebug() {
+
+		// If DEBUG, log error exit.
+
+		defer func() {
+			if debugMessageNumber > 0 {
+				initializer.debug(debugMessageNumber, redactedURL, err)
+			}
+		}()
+
+		// If TRACE, Log on entry/exit.
+
+		if initializer.getLogger().IsTrace() {
+			entryTime := time.Now()
+			initializer.traceEntry(120, redactedURL)
+			defer func() { initializer.traceExit(traceExitMessageNumber, redactedURL, err, time.Since(entryTime)) }()
+		}
+	}
+
+	// Determine what to create.
+
+	databaseName := getDatabaseName(parsedURL)
+	if len(databaseName) == 0 {
+		err = fmt.Errorf("no database name in database URL %s", redactedURL)
+		traceExitMessageNumber, debugMessageNumber = 121, 1121
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
+	}
+	if parsedURL.Scheme == "mssql" && len(initializer.DatabaseEncoding) > 0 {
+		initializer.log(3003, initializer.DatabaseEncoding, redactedURL)
+	}
+	createSQL, err := createDatabaseSQL(parsedURL.Scheme, databaseName, initializer.DatabaseEncoding, initializer.DatabaseCollation)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 127, 1127
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
+	}
+
+	// Connect to the server's maintenance database.
+
+	databaseConnector, err := connector.NewConnector(ctx, maintenanceDatabaseURL(parsedURL).String())
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 122, 1122
+		return wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+	database := sql.OpenDB(databaseConnector)
+	defer database.Close()
+
+	// If database exists, no more to do.
+
+	var count int
+	err = database.QueryRowContext(ctx, databaseExistsSQL[parsedURL.Scheme], databaseName).Scan(&count)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 123, 1123
+		return wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+	if count > 0 {
+		if initializer.DryRun {
+			_, err = fmt.Fprintf(initializer.getDryRunOutput(), "Database exists: %s\n", redactedURL)
+		}
+		traceExitMessageNumber, debugMessageNumber = 124, 0 // debugMessageNumber=0 because it's not an error.
+		return err                                          // Nothing more to do.
+	}
+
+	// Database doesn't exist.  In a dry run, only report that it would be created.
+
+	if initializer.DryRun {
+		_, err = fmt.Fprintf(initializer.getDryRunOutput(), "Database would be created: %s\n%s\n", redactedURL, createSQL)
+		traceExitMessageNumber, debugMessageNumber = 125, 0 // debugMessageNumber=0 because it's not an error.
+		return err
+	}
+
+	// Database doesn't exist, create it.
+
+	_, err = database.ExecContext(ctx, createSQL)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 126, 1126
+		return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
+	}
+	initializer.log(2003, databaseName, redactedURL)
+
+	// Notify observers.
+
+	if initializer.observers != nil {
+		go func() {
+			details := map[string]string{
+				"database":    databaseName,
+				"databaseUrl": redactedURL,
+			}
+			notifier.Notify(ctx, initializer.observers, initializer.ObserverOrigin, ComponentID, 8013, err, details)
+		}()
+	}
+	return err
+}
+
 func (initializer *BasicInitializer) initializeSpecificDatabaseSqlite(ctx context.Context, parsedURL *url.URL) error {
 	var err error
 
@@ -759,8 +1245,10 @@ func (initializer *BasicInitializer) initializeSpecificDatabaseSqlite(ctx contex
 
 		if initializer.getLogger().IsTrace() {
 			entryTime := time.Now()
-			initializer.traceEntry(100, parsedURL)
-			defer func() { initializer.traceExit(traceExitMessageNumber, parsedURL, err, time.Since(entryTime)) }()
+			initializer.traceEntry(100, parsedURL.Redacted())
+			defer func() {
+				initializer.traceExit(traceExitMessageNumber, parsedURL.Redacted(), err, time.Since(entryTime))
+			}()
 		}
 	}
 
@@ -777,22 +1265,38 @@ func (initializer *BasicInitializer) initializeSpecificDatabaseSqlite(ctx contex
 	filename = filepath.Clean(filename) // See https://securego.io/docs/rules/g304.html
 	_, err = os.Stat(filename)
 	if err == nil {
+		if initializer.DryRun {
+			_, err = fmt.Fprintf(initializer.getDryRunOutput(), "SQLite file exists: %s\n", filename)
+		}
 		traceExitMessageNumber, debugMessageNumber = 101, 0 // debugMessageNumber=0 because it's not an error.
 		return err                                          // Nothing more to do.
 	}
 
+	// File doesn't exist.  In a dry run, only report that it would be created.
+
+	if initializer.DryRun {
+		_, err = fmt.Fprintf(initializer.getDryRunOutput(), "SQLite file would be created: %s\n", filename)
+		traceExitMessageNumber, debugMessageNumber = 104, 0 // debugMessageNumber=0 because it's not an error.
+		return err
+	}
+
 	// File doesn't exist, create it.
 
 	path := filepath.Dir(filename)
 	err = os.MkdirAll(path, os.ModePerm)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 102, 1102
-		return err
+		return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
 	}
-	_, err = os.Create(filename)
+	file, err := os.Create(filename)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 103, 1103
-		return err
+		return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
+	}
+	err = file.Close()
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 105, 1105
+		return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
 	}
 	initializer.log(2001, filename)
 
@@ -808,3 +1312,141 @@ func (initializer *BasicInitializer) initializeSpecificDatabaseSqlite(ctx contex
 	}
 	return err
 }
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+NewObserver creates the observer named by an observer URL, as BasicInitializer does for ObserverURL.
+Callers that use senzingconfig or senzingschema directly, such as the config subcommands,
+use it so an observer URL means the same thing as it does to Initialize.
+Only the "grpc" scheme is supported; for other schemes, no observer is returned.
+
+Input
+  - ctx: A context to control lifecycle.
+  - observerURL: The URL of the observer.  Example: grpc://localhost:8260
+*/
+func NewObserver(ctx context.Context, observerURL string) (observer.Observer, error) {
+	var result observer.Observer
+	parsedURL, err := url.Parse(observerURL)
+	if err != nil {
+		return result, err
+	}
+	switch parsedURL.Scheme {
+	case "grpc":
+		result, err = createGrpcObserver(ctx, *parsedURL)
+	default:
+	}
+	return result, err
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func createGrpcObserver(ctx context.Context, parsedURL url.URL) (observer.Observer, error) {
+	_ = ctx
+	var err error
+
+	var result observer.Observer
+
+	port := DefaultGrpcObserverPort
+	if len(parsedURL.Port()) > 0 {
+		port = parsedURL.Port()
+	}
+	target := fmt.Sprintf("%s:%s", parsedURL.Hostname(), port)
+
+	// TODO: Allow specification of options from ObserverUrl/parsedUrl
+	grpcOptions := grpc.WithTransportCredentials(insecure.NewCredentials())
+
+	grpcConnection, err := grpc.NewClient(target, grpcOptions)
+	if err != nil {
+		return result, err
+	}
+	result = &observer.GrpcObserver{
+		GrpcClient: observerpb.NewObserverClient(grpcConnection),
+		ID:         "init-database",
+	}
+	return result, err
+}
+
+// Connect to a database and ping it, giving up after timeout.
+func pingDatabase(ctx context.Context, databaseURL string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	databaseConnector, err := connector.NewConnector(ctx, databaseURL)
+	if err != nil {
+		return err
+	}
+	database := sql.OpenDB(databaseConnector)
+	defer database.Close()
+	return database.PingContext(ctx)
+}
+
+// The CREATE DATABASE statement for a database scheme.
+func createDatabaseSQL(scheme string, databaseName string, encoding string, collation string) (string, error) {
+	var result string
+	for _, name := range []string{encoding, collation} {
+		if len(name) > 0 && !regexpCharacterSetName.MatchString(name) {
+			return result, fmt.Errorf("invalid database encoding or collation: %q", name)
+		}
+	}
+	switch scheme {
+	case "mssql":
+		result = "CREATE DATABASE [" + strings.ReplaceAll(databaseName, "]", "]]") + "]"
+		if len(collation) > 0 {
+			result += " COLLATE " + collation
+		}
+	case "mysql":
+		result = "CREATE DATABASE `" + strings.ReplaceAll(databaseName, "`", "``") + "`"
+		if len(encoding) > 0 {
+			result += " CHARACTER SET " + encoding
+		}
+		if len(collation) > 0 {
+			result += " COLLATE " + collation
+		}
+	case "postgresql":
+		result = `CREATE DATABASE "` + strings.ReplaceAll(databaseName, `"`, `""`) + `"`
+		if len(encoding) > 0 {
+			result += " ENCODING '" + encoding + "'"
+		}
+		if len(collation) > 0 {
+			result += " LC_COLLATE '" + collation + "' LC_CTYPE '" + collation + "'"
+		}
+		if len(encoding) > 0 || len(collation) > 0 {
+			result += " TEMPLATE template0" // template1 may have an incompatible encoding or collation.
+		}
+	default:
+		return result, fmt.Errorf("cannot create database for database scheme: %s", scheme)
+	}
+	return result, nil
+}
+
+// The name of the database in a database URL, found as go-databasing's connector finds it.
+func getDatabaseName(parsedURL *url.URL) string {
+	result := strings.ReplaceAll(parsedURL.Path, "/", "")
+	query := parsedURL.Query()
+	switch parsedURL.Scheme {
+	case "mssql":
+		if database := query.Get("database"); len(database) > 0 {
+			result = database
+		}
+	case "mysql":
+		if len(result) == 0 {
+			result = query.Get("schema")
+		}
+	}
+	return result
+}
+
+// A copy of a database URL that names the server's maintenance database instead.
+func maintenanceDatabaseURL(parsedURL *url.URL) *url.URL {
+	result := *parsedURL
+	result.Path = "/" + maintenanceDatabases[parsedURL.Scheme]
+	query := parsedURL.Query()
+	query.Del("database")
+	query.Del("schema")
+	result.RawQuery = query.Encode()
+	return &result
+}
@@ -0,0 +1,303 @@
+package initializer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/senzing-garage/go-databasing/connector"
+	"github.com/senzing-garage/go-databasing/dbhelper"
+	"github.com/senzing-garage/go-helpers/settingsparser"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A lock held for the duration of initialization.
+// Database locks belong to a dedicated connection, so they are released if the process dies.
+type initializationLock struct {
+	argument   interface{}
+	connection *sql.Conn
+	database   *sql.DB
+	file       *os.File
+	name       string
+	queries    lockQueries
+}
+
+// Per database scheme, how to take and release a named lock without waiting.
+// The tryLock query returns 1 if the lock was acquired.
+type lockQueries struct {
+	tryLock string
+	unlock  string
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var lockQueriesByScheme = map[string]lockQueries{
+	"mssql": {
+		tryLock: "DECLARE @result int; EXEC @result = sp_getapplock @Resource = @p1, @LockMode = 'Exclusive', @LockOwner = 'Session', @LockTimeout = 0; SELECT CASE WHEN @result >= 0 THEN 1 ELSE 0 END",
+		unlock:  "EXEC sp_releaseapplock @Resource = @p1, @LockOwner = 'Session'",
+	},
+	"mysql": {
+		tryLock: "SELECT COALESCE(GET_LOCK(?, 0), 0)",
+		unlock:  "SELECT RELEASE_LOCK(?)",
+	},
+	"postgresql": {
+		tryLock: "SELECT CASE WHEN pg_try_advisory_lock($1) THEN 1 ELSE 0 END",
+		unlock:  "SELECT pg_advisory_unlock($1)",
+	},
+}
+
+// How often a held lock is tried again.
+var lockPollInterval = time.Second
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+/*
+Take the initialization locks for every database, waiting while another process holds one.
+Locks are taken in the sorted order of the databases they guard, so two processes cannot deadlock on each other.
+Databases without a lock are skipped.
+*/
+func (initializer *BasicInitializer) acquireInitializationLock(ctx context.Context) ([]*initializationLock, error) {
+	var result []*initializationLock
+	parser, err := settingsparser.New(initializer.SenzingSettings)
+	if err != nil {
+		return result, err
+	}
+	databaseURLs, err := initializer.getDatabaseURLs(ctx, parser)
+	if err != nil {
+		return result, err
+	}
+
+	// Several database URLs may name the same database; lock each database once.
+
+	parsedURLs := map[string]*url.URL{}
+	for _, databaseURL := range databaseURLs {
+		var parsedURL *url.URL
+		parsedURL, err = dbhelper.ParseDatabaseURL(databaseURL)
+		if err != nil {
+			return result, err
+		}
+		parsedURLs[lockIdentity(parsedURL)] = parsedURL
+	}
+	identities := make([]string, 0, len(parsedURLs))
+	for identity := range parsedURLs {
+		identities = append(identities, identity)
+	}
+	sort.Strings(identities)
+
+	if initializer.LockTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, initializer.LockTimeout)
+		defer cancel()
+	}
+	for _, identity := range identities {
+		var lock *initializationLock
+		lock, err = initializer.newInitializationLock(ctx, parsedURLs[identity])
+		if err == nil && lock != nil {
+			err = initializer.takeInitializationLock(ctx, lock)
+		}
+		if err != nil {
+			initializer.releaseInitializationLock(ctx, result)
+			return nil, err
+		}
+		if lock != nil {
+			result = append(result, lock)
+		}
+	}
+	return result, err
+}
+
+// Release initialization locks in the reverse of the order they were taken.
+func (initializer *BasicInitializer) releaseInitializationLock(ctx context.Context, locks []*initializationLock) {
+	for index := len(locks) - 1; index >= 0; index-- {
+		lock := locks[index]
+		err := lock.unlock(ctx)
+		if err != nil {
+			initializer.log(3005, lock.name, err)
+			continue
+		}
+		initializer.log(2005, lock.name)
+	}
+}
+
+// Take a lock, waiting while another process holds it.  On failure, the lock's resources are released.
+func (initializer *BasicInitializer) takeInitializationLock(ctx context.Context, lock *initializationLock) error {
+	waiting := false
+	for {
+		acquired, err := lock.tryLock(ctx)
+		if err != nil {
+			lock.close()
+			return err
+		}
+		if acquired {
+			initializer.log(2004, lock.name)
+			return err
+		}
+		if !waiting {
+			initializer.log(3004, lock.name)
+			waiting = true
+		}
+		select {
+		case <-ctx.Done():
+			lock.close()
+			return fmt.Errorf("timed out waiting for initialization lock %s: %w", lock.name, ctx.Err())
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
+
+// Prepare, but do not take, the lock for a database.
+func (initializer *BasicInitializer) newInitializationLock(ctx context.Context, parsedURL *url.URL) (*initializationLock, error) {
+	var err error
+
+	// SQLite: lock a file next to the database file.
+
+	if parsedURL.Scheme == "sqlite3" {
+		queryParameters := parsedURL.Query()
+		if (queryParameters.Get("mode") == "memory") && (queryParameters.Get("cache") == "shared") {
+			return nil, err // An in-memory database cannot be shared between processes.
+		}
+		filename := filepath.Clean(parsedURL.Path) + ".init-database.lock"
+		err = os.MkdirAll(filepath.Dir(filename), os.ModePerm)
+		if err != nil {
+			return nil, err
+		}
+		var file *os.File
+		file, err = os.OpenFile(filename, os.O_CREATE|os.O_RDWR, 0o600)
+		if err != nil {
+			return nil, err
+		}
+		return &initializationLock{
+			file: file,
+			name: filename,
+		}, err
+	}
+
+	// Other databases: a named lock on a dedicated connection to the server's maintenance database.
+	// The maintenance database is used because the database itself may not exist yet and
+	// because PostgreSQL and MS SQL scope their locks to a database.
+
+	queries, ok := lockQueriesByScheme[parsedURL.Scheme]
+	if !ok {
+		initializer.log(3006, parsedURL.Redacted())
+		return nil, err
+	}
+	databaseName := getDatabaseName(parsedURL)
+	databaseConnector, err := connector.NewConnector(ctx, maintenanceDatabaseURL(parsedURL).String())
+	if err != nil {
+		return nil, err
+	}
+	database := sql.OpenDB(databaseConnector)
+	connection, err := database.Conn(ctx)
+	if err != nil {
+		_ = database.Close()
+		return nil, err
+	}
+	result := &initializationLock{
+		argument:   "init-database:" + databaseName,
+		connection: connection,
+		database:   database,
+		name:       fmt.Sprintf("init-database:%s on %s", databaseName, parsedURL.Redacted()),
+		queries:    queries,
+	}
+	if parsedURL.Scheme == "postgresql" {
+		result.argument = lockKey(databaseName) // PostgreSQL advisory locks are identified by number.
+	}
+	return result, err
+}
+
+// ----------------------------------------------------------------------------
+// initializationLock methods
+// ----------------------------------------------------------------------------
+
+// Release resources without unlocking.
+func (lock *initializationLock) close() {
+	if lock.file != nil {
+		_ = lock.file.Close()
+	}
+	if lock.connection != nil {
+		_ = lock.connection.Close()
+	}
+	if lock.database != nil {
+		_ = lock.database.Close()
+	}
+}
+
+// Try to take the lock without waiting.
+func (lock *initializationLock) tryLock(ctx context.Context) (bool, error) {
+	if lock.file != nil {
+		acquired, err := tryLockFile(lock.file)
+		if err != nil || !acquired {
+			return acquired, err
+		}
+
+		// The previous holder removes the file before unlocking it; if so, lock the file now at the path instead.
+
+		if isSameFile(lock.file, lock.name) {
+			return true, err
+		}
+		_ = unlockFile(lock.file)
+		_ = lock.file.Close()
+		lock.file, err = os.OpenFile(lock.name, os.O_CREATE|os.O_RDWR, 0o600)
+		return false, err
+	}
+	var acquired int
+	err := lock.connection.QueryRowContext(ctx, lock.queries.tryLock, lock.argument).Scan(&acquired)
+	return acquired == 1, err
+}
+
+// Release the lock and its resources.
+func (lock *initializationLock) unlock(ctx context.Context) error {
+	var err error
+	defer lock.close()
+	if lock.file != nil {
+		_ = os.Remove(lock.name) // While still held, so a waiter cannot lock the removed file unnoticed.  Fails harmlessly on Windows.
+		return unlockFile(lock.file)
+	}
+	_, err = lock.connection.ExecContext(ctx, lock.queries.unlock, lock.argument)
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// True when an open file is still the file at a path.
+func isSameFile(file *os.File, path string) bool {
+	openInfo, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	pathInfo, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(openInfo, pathInfo)
+}
+
+// What an initialization lock guards: the SQLite file, or the database on a server.
+func lockIdentity(parsedURL *url.URL) string {
+	if parsedURL.Scheme == "sqlite3" {
+		return parsedURL.Scheme + ":" + filepath.Clean(parsedURL.Path)
+	}
+	return parsedURL.Scheme + "://" + parsedURL.Host + "/" + getDatabaseName(parsedURL)
+}
+
+// A PostgreSQL advisory lock key for a database name.
+func lockKey(databaseName string) int64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte("init-database:" + databaseName))
+	return int64(hash.Sum64() >> 1)
+}
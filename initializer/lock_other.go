@@ -0,0 +1,23 @@
+//go:build !windows
+
+package initializer
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// Try to take an exclusive lock on a file without waiting.  The lock is released when the file is closed.
+func tryLockFile(file *os.File) (bool, error) {
+	err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Release a lock taken by tryLockFile.
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,26 @@
+//go:build windows
+
+package initializer
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// Try to take an exclusive lock on a file without waiting.  The lock is released when the file is closed.
+func tryLockFile(file *os.File) (bool, error) {
+	overlapped := &windows.Overlapped{}
+	err := windows.LockFileEx(windows.Handle(file.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, overlapped)
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Release a lock taken by tryLockFile.
+func unlockFile(file *os.File) error {
+	overlapped := &windows.Overlapped{}
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, 1, 0, overlapped)
+}
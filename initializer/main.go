@@ -43,11 +43,14 @@ var IDMessages = map[int]string{
 	14:   "Exit  " + Prefix + "Initialize(); senzingSchema.InitializeSenzing failed; returned (%v).",
 	15:   "Exit  " + Prefix + "Initialize(); senzingConfig.SetLogLevel failed; returned (%v).",
 	16:   "Exit  " + Prefix + "Initialize(); senzingConfig.InitializeSenzing; returned (%v).",
-	17:   "Exit  " + Prefix + "Initialize(); initializerImpl.observers.RegisterObserver; returned (%v).",
-	18:   "Exit  " + Prefix + "Initialize(); initializerImpl.createGrpcObserver; returned (%v).",
+	18:   "Exit  " + Prefix + "Initialize(); initializerImpl.createObserver; returned (%v).",
 	19:   "Exit  " + Prefix + "Initialize(); initializerImpl.registerObserverSenzingSchema; returned (%v).",
 	20:   "Exit  " + Prefix + "Initialize(); initializerImpl.registerObserverSenzingConfig; returned (%v).",
 	21:   "Exit  " + Prefix + "Initialize(); os.Stat failed; returned (%v).",
+	22:   "Exit  " + Prefix + "Initialize(); initializerImpl.waitForDatabases failed; returned (%v).",
+	23:   "Exit  " + Prefix + "Initialize(); granting runtime role access failed; returned (%v).",
+	24:   "Exit  " + Prefix + "Initialize(); initializerImpl.acquireInitializationLock failed; returned (%v).",
+	25:   "Exit  " + Prefix + "Initialize(); initializerImpl.getLogger failed; returned (%v).",
 	29:   "Exit  " + Prefix + "Initialize() returned (%v).",
 	40:   "Enter " + Prefix + "InitializeSpecificDatabase().",
 	41:   "Exit  " + Prefix + "InitializeSpecificDatabase(); json.Marshal failed; returned (%v).",
@@ -55,6 +58,8 @@ var IDMessages = map[int]string{
 	43:   "Exit  " + Prefix + "InitializeSpecificDatabase(); parser.GetDatabaseUrls failed; returned (%v).",
 	44:   "Exit  " + Prefix + "InitializeSpecificDatabase(); url.Parse failed; returned (%v).",
 	45:   "Exit  " + Prefix + "InitializeSpecificDatabase(); initializerImpl.initializeSpecificDatabaseSqlite; returned (%v).",
+	46:   "Exit  " + Prefix + "InitializeSpecificDatabase(); initializerImpl.initializeSpecificDatabaseServer; returned (%v).",
+	47:   "Exit  " + Prefix + "InitializeSpecificDatabase(); initializerImpl.getLogger failed; returned (%v).",
 	49:   "Exit  " + Prefix + "InitializeSpecificDatabase() returned (%v).",
 	50:   "Enter " + Prefix + "RegisterObserver(%s).",
 	51:   "Exit  " + Prefix + "RegisterObserver(%s); json.Marshal failed; returned (%v).",
@@ -78,30 +83,63 @@ var IDMessages = map[int]string{
 	80:   "Enter " + Prefix + "SetObserverOrigin(%s).",
 	81:   "Exit  " + Prefix + "SetObserverOrigin(%s); json.Marshal failed; returned (%v).",
 	89:   "Exit  " + Prefix + "SetObserverOrigin(%s).",
+	90:   "Enter " + Prefix + "Migrate().",
+	91:   "Exit  " + Prefix + "Migrate(); json.Marshal failed; returned (%v).",
+	92:   "Exit  " + Prefix + "Migrate(); initializerImpl.createObserver; returned (%v).",
+	93:   "Exit  " + Prefix + "Migrate(); senzingSchema.SetLogLevel failed; returned (%v).",
+	94:   "Exit  " + Prefix + "Migrate(); initializerImpl.registerObserverSenzingSchema; returned (%v).",
+	95:   "Exit  " + Prefix + "Migrate(); senzingSchema.Migrate failed; returned (%v).",
+	96:   "Exit  " + Prefix + "Migrate(); initializerImpl.acquireInitializationLock failed; returned (%v).",
+	97:   "Exit  " + Prefix + "Migrate(); initializerImpl.getLogger failed; returned (%v).",
+	99:   "Exit  " + Prefix + "Migrate() returned (%v).",
 	100:  "Enter " + Prefix + "initializeSpecificDatabaseSqlite(%v).",
 	101:  "Exit  " + Prefix + "initializeSpecificDatabaseSqlite(%v); os.Stat failed; returned (%v).",
 	102:  "Exit  " + Prefix + "initializeSpecificDatabaseSqlite(%v); os.MkdirAll failed; returned (%v).",
 	103:  "Exit  " + Prefix + "initializeSpecificDatabaseSqlite(%v); os.Create failed; returned (%v).",
+	104:  "Exit  " + Prefix + "initializeSpecificDatabaseSqlite(%v); dry run; returned (%v).",
+	105:  "Exit  " + Prefix + "initializeSpecificDatabaseSqlite(%v); file.Close failed; returned (%v).",
 	109:  "Exit  " + Prefix + "initializeSpecificDatabaseSqlite(%v) returned (%v).",
+	110:  "Enter " + Prefix + "waitForDatabase().",
+	111:  "Exit  " + Prefix + "waitForDatabase(); url.Parse failed; returned (%v).",
+	112:  "Exit  " + Prefix + "waitForDatabase(); SQLite needs no wait; returned (%v).",
+	113:  "Exit  " + Prefix + "waitForDatabase(); context done; returned (%v).",
+	114:  "Exit  " + Prefix + "waitForDatabase(); database not ready; returned (%v).",
+	119:  "Exit  " + Prefix + "waitForDatabase() returned (%v).",
+	120:  "Enter " + Prefix + "initializeSpecificDatabaseServer(%s).",
+	121:  "Exit  " + Prefix + "initializeSpecificDatabaseServer(%s); no database name; returned (%v).",
+	122:  "Exit  " + Prefix + "initializeSpecificDatabaseServer(%s); connector.NewConnector failed; returned (%v).",
+	123:  "Exit  " + Prefix + "initializeSpecificDatabaseServer(%s); database query failed; returned (%v).",
+	124:  "Exit  " + Prefix + "initializeSpecificDatabaseServer(%s); database exists; returned (%v).",
+	125:  "Exit  " + Prefix + "initializeSpecificDatabaseServer(%s); dry run; returned (%v).",
+	126:  "Exit  " + Prefix + "initializeSpecificDatabaseServer(%s); CREATE DATABASE failed; returned (%v).",
+	127:  "Exit  " + Prefix + "initializeSpecificDatabaseServer(%s); createDatabaseSQL failed; returned (%v).",
+	129:  "Exit  " + Prefix + "initializeSpecificDatabaseServer(%s) returned (%v).",
 	1000: Prefix + "Initialize parameters: %+v",
 	1001: Prefix + "InitializeSpecificDatabase parameters: %+v",
 	1002: Prefix + "RegisterObserver parameters: %+v",
 	1003: Prefix + "SetLogLevel parameters: %+v",
 	1004: Prefix + "SetObserverOrigin parameters: %+v",
 	1005: Prefix + "UnregisterObserver parameters: %+v",
+	1006: Prefix + "Migrate parameters: %+v",
 	1011: Prefix + "Initialize(); json.Marshal failed; Error: %v.",
 	1012: Prefix + "Initialize(); initializerImpl.InitializeSpecificDatabase failed; Error: %v.",
 	1013: Prefix + "Initialize(); initializerImpl.getSenzingSchema failed; Error: %v.",
 	1014: Prefix + "Initialize(); senzingSchema.InitializeSenzing failed; Error: %v.",
 	1015: Prefix + "Initialize(); initializerImpl.getSenzingConfig failed; Error: %v.",
 	1016: Prefix + "Initialize(); senzingConfig.InitializeSenzing; Error: %v.",
-	1017: Prefix + "Initialize(); initializerImpl.observers.RegisterObserver; returned (%v).",
-	1018: Prefix + "Initialize(); initializerImpl.createGrpcObserver; returned (%v).",
+	1018: Prefix + "Initialize(); initializerImpl.createObserver; returned (%v).",
+	1019: Prefix + "Initialize(); initializerImpl.registerObserverSenzingSchema; returned (%v).",
+	1022: Prefix + "Initialize(); initializerImpl.waitForDatabases failed; Error: %v.",
+	1023: Prefix + "Initialize(); granting runtime role access failed; Error: %v.",
+	1024: Prefix + "Initialize(); initializerImpl.acquireInitializationLock failed; Error: %v.",
+	1025: Prefix + "Initialize(); initializerImpl.getLogger failed; Error: %v.",
 	1041: Prefix + "InitializeSpecificDatabase(); json.Marshal failed; Error: %v.",
 	1042: Prefix + "InitializeSpecificDatabase(); settingsparser.New failed; Error: %v.",
 	1043: Prefix + "InitializeSpecificDatabase(); parser.GetDatabaseUrls failed; Error: %v.",
 	1044: Prefix + "InitializeSpecificDatabase(); url.Parse failed; Error: %v.",
 	1045: Prefix + "InitializeSpecificDatabase(); initializerImpl.initializeSpecificDatabaseSqlite; Error: %v.",
+	1046: Prefix + "InitializeSpecificDatabase(); initializerImpl.initializeSpecificDatabaseServer; Error: %v.",
+	1047: Prefix + "InitializeSpecificDatabase(); initializerImpl.getLogger failed; Error: %v.",
 	1051: Prefix + "RegisterObserver(%s); json.Marshal failed; Error: %v.",
 	1052: Prefix + "RegisterObserver(%s); initializerImpl.observers.RegisterObserver failed; Error: %v.",
 	1053: Prefix + "RegisterObserver(%s); initializerImpl.getSenzingConfig().RegisterObserver failed; Error: %v.",
@@ -117,11 +155,36 @@ var IDMessages = map[int]string{
 	1074: Prefix + "UnregisterObserver(%s); initializerImpl.observers.UnregisterObserver failed; Error: %v.",
 	1075: Prefix + "Initialize(); os.Stat failed; Error: %v.",
 	1081: Prefix + "SetObserverOrigin(%s); json.Marshal failed; Error: %v.",
-	1101: Prefix + "initializeSpecificDatabaseSqlite(%v); os.Stat failed; returned (%v).",
+	1091: Prefix + "Migrate(); json.Marshal failed; Error: %v.",
+	1092: Prefix + "Migrate(); initializerImpl.createObserver; Error: %v.",
+	1093: Prefix + "Migrate(); senzingSchema.SetLogLevel failed; Error: %v.",
+	1094: Prefix + "Migrate(); initializerImpl.registerObserverSenzingSchema; Error: %v.",
+	1095: Prefix + "Migrate(); senzingSchema.Migrate failed; Error: %v.",
+	1096: Prefix + "Migrate(); initializerImpl.acquireInitializationLock failed; Error: %v.",
+	1097: Prefix + "Migrate(); initializerImpl.getLogger failed; Error: %v.",
 	1102: Prefix + "initializeSpecificDatabaseSqlite(%v); os.MkdirAll failed; returned (%v).",
 	1103: Prefix + "initializeSpecificDatabaseSqlite(%v); os.Create failed; returned (%v).",
+	1105: Prefix + "initializeSpecificDatabaseSqlite(%v); file.Close failed; returned (%v).",
+	1111: Prefix + "waitForDatabase(); url.Parse failed; returned (%v).",
+	1113: Prefix + "waitForDatabase(); context done; returned (%v).",
+	1114: Prefix + "waitForDatabase(); database not ready; returned (%v).",
+	1121: Prefix + "initializeSpecificDatabaseServer(%s); no database name; returned (%v).",
+	1122: Prefix + "initializeSpecificDatabaseServer(%s); connector.NewConnector failed; returned (%v).",
+	1123: Prefix + "initializeSpecificDatabaseServer(%s); database query failed; returned (%v).",
+	1126: Prefix + "initializeSpecificDatabaseServer(%s); CREATE DATABASE failed; returned (%v).",
+	1127: Prefix + "initializeSpecificDatabaseServer(%s); createDatabaseSQL failed; returned (%v).",
 	2001: "Created file: %s",
+	2002: "Database %s is ready after %d attempt(s)",
+	2003: "Created database %s on %s",
+	2004: "Acquired initialization lock %s",
+	2005: "Released initialization lock %s",
 	3001: "SQL file does not exist: %s",
+	3002: "Database %s is not ready (attempt %d of %d).  Retrying in %s.  Error: %v",
+	3003: "Database encoding %s does not apply to MS SQL database %s.  Use a collation instead.",
+	3004: "Waiting for initialization lock %s, which another process holds",
+	3005: "Could not release initialization lock %s; error: %v",
+	3006: "Database %s has no initialization lock.  Concurrent runs of init-database are not prevented.",
+	4001: "Database %s is not ready after %d attempts.  Error: %v",
 	8001: Prefix + "Initialize Observer URL",
 	8002: Prefix + "Initialize",
 	8003: Prefix + "RegisterObserver",
@@ -129,6 +192,9 @@ var IDMessages = map[int]string{
 	8005: Prefix + "SetObserverOrigin",
 	8006: Prefix + "UnregisterObserver",
 	8010: Prefix + "initializeSpecificDatabaseSqlite",
+	8011: Prefix + "Migrate",
+	8012: Prefix + "waitForDatabase",
+	8013: Prefix + "initializeSpecificDatabaseServer",
 }
 
 // Status strings for specific messages.
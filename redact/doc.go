@@ -0,0 +1,5 @@
+/*
+Package redact masks passwords and license strings in database URLs, Senzing settings
+and the JSON of init-database's structs before they are logged or sent to observers.
+*/
+package redact
@@ -0,0 +1,116 @@
+package redact
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// What a secret is replaced with.  The same mask as url.URL.Redacted().
+const Mask = "xxxxx"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// The password of a URL's userinfo, anywhere in text.  Group 1 is everything before the password.
+var regexpURLPassword = regexp.MustCompile(`([A-Za-z][A-Za-z0-9+.-]*://[^:@/\s"]*:)[^@/\s"]*@`)
+
+// Lower-case fragments of JSON keys whose values are secret.
+var secretKeys = []string{
+	"licensestring",
+	"password",
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+DatabaseURL masks the passwords of any URLs in text.
+Unlike url.URL.Redacted(), it accepts Senzing's "host:port:database" form of database URL.
+*/
+func DatabaseURL(text string) string {
+	return regexpURLPassword.ReplaceAllString(text, "${1}"+Mask+"@")
+}
+
+/*
+JSON masks secrets in a JSON document:
+
+  - Values of keys naming a password or license string.
+  - Passwords of URLs in keys and values.
+  - The same, within string values that are themselves JSON documents, such as Senzing settings.
+
+Text that is not JSON has only its URL passwords masked.
+*/
+func JSON(text string) string {
+	var document interface{}
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber() // Keep large identifiers, such as configuration IDs, exact.
+	err := decoder.Decode(&document)
+	if err != nil || decoder.More() {
+		return DatabaseURL(text)
+	}
+	result, err := json.Marshal(redactValue("", document))
+	if err != nil {
+		return DatabaseURL(text)
+	}
+	return string(result)
+}
+
+// Marshal returns the JSON encoding of a value with its secrets masked.
+func Marshal(value interface{}) ([]byte, error) {
+	result, err := json.Marshal(value)
+	if err != nil {
+		return result, err
+	}
+	return []byte(JSON(string(result))), err
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Whether a JSON key names a secret.
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, secretKey := range secretKeys {
+		if strings.Contains(key, secretKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// Mask the secrets in a decoded JSON value found under a key.
+func redactValue(key string, value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for childKey, childValue := range value {
+			result[DatabaseURL(childKey)] = redactValue(childKey, childValue)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for index, childValue := range value {
+			result[index] = redactValue(key, childValue)
+		}
+		return result
+	case string:
+		if len(value) > 0 && isSecretKey(key) {
+			return Mask
+		}
+		trimmed := strings.TrimSpace(value)
+		if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+			return JSON(value)
+		}
+		return DatabaseURL(value)
+	default:
+		return value
+	}
+}
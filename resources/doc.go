@@ -0,0 +1,5 @@
+/*
+Package resources holds copies of the Senzing schema SQL files and configuration template
+used when the Senzing resource path does not provide them.
+*/
+package resources
@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Path, relative to a Senzing resource path, of the Senzing configuration template.
+const ConfigTemplate = "templates/g2config.json"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Copies of files from the Senzing resource path (/opt/senzing/er/resources).
+// Keep in step with rootfs/opt/senzing/er/resources.
+//
+//go:embed schema/*.sql templates/g2config.json
+var files embed.FS
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The IsMissing function returns true when a file, given relative to a Senzing resource path,
+does not exist in the resource path but an embedded copy does.
+
+Input
+  - resourcePath: The Senzing resource path. Example: /opt/senzing/er/resources
+  - name: The path of the file relative to resourcePath. Example: schema/szcore-schema-sqlite-create.sql
+*/
+func IsMissing(resourcePath string, name string) bool {
+	_, err := os.Stat(filepath.Join(resourcePath, filepath.FromSlash(name)))
+	if !errors.Is(err, fs.ErrNotExist) {
+		return false
+	}
+	_, err = fs.Stat(files, name)
+	return err == nil
+}
+
+/*
+The ReadFile function returns the embedded copy of a file.
+
+Input
+  - name: The path of the file relative to a Senzing resource path. Example: templates/g2config.json
+*/
+func ReadFile(name string) ([]byte, error) {
+	return files.ReadFile(name)
+}
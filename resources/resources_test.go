@@ -0,0 +1,45 @@
+package resources
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const rootfsResourcePath = "../rootfs/opt/senzing/er/resources"
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestIsMissing(test *testing.T) {
+	require.False(test, IsMissing(rootfsResourcePath, ConfigTemplate))
+	require.True(test, IsMissing(test.TempDir(), ConfigTemplate))
+	require.False(test, IsMissing(test.TempDir(), "templates/no-such-file.json"))
+}
+
+func TestReadFile(test *testing.T) {
+	_, err := ReadFile("schema/szcore-schema-sqlite-create.sql")
+	require.NoError(test, err)
+	_, err = ReadFile("schema/no-such-file.sql")
+	require.Error(test, err)
+}
+
+// The embedded copies must match the files installed into the Docker image.
+func TestReadFile_matchesRootfs(test *testing.T) {
+	err := fs.WalkDir(files, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		embedded, err := ReadFile(path)
+		require.NoError(test, err)
+		installed, err := os.ReadFile(filepath.Join(rootfsResourcePath, filepath.FromSlash(path)))
+		require.NoError(test, err)
+		require.Equal(test, installed, embedded, path)
+		return nil
+	})
+	require.NoError(test, err)
+}
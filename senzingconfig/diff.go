@@ -0,0 +1,202 @@
+package senzingconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A table of a Senzing configuration compared item by item, matching items by their code.
+type configSection struct {
+	name     string
+	table    string
+	keyField string
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Sections compared item by item, in the order they are reported.
+var configSections = []configSection{
+	{name: ConfigSectionDataSources, table: "CFG_DSRC", keyField: "DSRC_CODE"},
+	{name: ConfigSectionFeatures, table: "CFG_FTYPE", keyField: "FTYPE_CODE"},
+	{name: ConfigSectionAttributes, table: "CFG_ATTR", keyField: "ATTR_CODE"},
+	{name: ConfigSectionRules, table: "CFG_ERRULE", keyField: "ERRULE_CODE"},
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+CompareConfigs reports how one Senzing configuration differs from another.
+Datasources, features, attributes and rules are matched by code and compared field by field.
+The remaining tables of G2_CONFIG are compared whole and reported in ConfigSectionOther.
+Both configurations are normalized first, so key order and Senzing build fields are ignored.
+
+Input
+  - before: The Senzing configuration JSON compared from.
+  - after: The Senzing configuration JSON compared to.
+
+Output
+  - The differences, by section in the order datasources, features, attributes, rules, other, then by key.
+*/
+func CompareConfigs(before string, after string) ([]ConfigDifference, error) {
+	result := []ConfigDifference{}
+	beforeTables, err := parseConfigTables(before)
+	if err != nil {
+		return result, fmt.Errorf("first Senzing configuration: %w", err)
+	}
+	afterTables, err := parseConfigTables(after)
+	if err != nil {
+		return result, fmt.Errorf("second Senzing configuration: %w", err)
+	}
+
+	// Compare sections item by item.
+
+	compared := map[string]bool{}
+	for _, section := range configSections {
+		compared[section.table] = true
+		differences, err := compareSection(section, beforeTables[section.table], afterTables[section.table])
+		if err != nil {
+			return result, err
+		}
+		result = append(result, differences...)
+	}
+
+	// Compare the remaining tables whole.
+
+	tables := []string{}
+	for _, configTables := range []map[string]json.RawMessage{beforeTables, afterTables} {
+		for table := range configTables {
+			if !compared[table] {
+				compared[table] = true
+				tables = append(tables, table)
+			}
+		}
+	}
+	sort.Strings(tables)
+	for _, table := range tables {
+		beforeTable, inBefore := beforeTables[table]
+		afterTable, inAfter := afterTables[table]
+		switch {
+		case !inBefore:
+			result = append(result, ConfigDifference{Change: ConfigChangeAdded, Key: table, Section: ConfigSectionOther})
+		case !inAfter:
+			result = append(result, ConfigDifference{Change: ConfigChangeRemoved, Key: table, Section: ConfigSectionOther})
+		case !bytes.Equal(beforeTable, afterTable):
+			result = append(result, ConfigDifference{Change: ConfigChangeChanged, Key: table, Section: ConfigSectionOther})
+		}
+	}
+	return result, err
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Compare the items of one section, matched by code.
+func compareSection(section configSection, beforeTable json.RawMessage, afterTable json.RawMessage) ([]ConfigDifference, error) {
+	result := []ConfigDifference{}
+	beforeItems, err := parseConfigItems(section, beforeTable)
+	if err != nil {
+		return result, err
+	}
+	afterItems, err := parseConfigItems(section, afterTable)
+	if err != nil {
+		return result, err
+	}
+	keys := []string{}
+	for key := range beforeItems {
+		keys = append(keys, key)
+	}
+	for key := range afterItems {
+		if _, ok := beforeItems[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		beforeItem, inBefore := beforeItems[key]
+		afterItem, inAfter := afterItems[key]
+		switch {
+		case !inBefore:
+			result = append(result, ConfigDifference{Change: ConfigChangeAdded, Key: key, Section: section.name})
+		case !inAfter:
+			result = append(result, ConfigDifference{Change: ConfigChangeRemoved, Key: key, Section: section.name})
+		default:
+			fields := compareItems(beforeItem, afterItem)
+			if len(fields) > 0 {
+				result = append(result, ConfigDifference{Change: ConfigChangeChanged, Fields: fields, Key: key, Section: section.name})
+			}
+		}
+	}
+	return result, err
+}
+
+// The fields of two items that differ, in field name order.
+func compareItems(before map[string]json.RawMessage, after map[string]json.RawMessage) []ConfigFieldChange {
+	result := []ConfigFieldChange{}
+	fields := []string{}
+	for field := range before {
+		fields = append(fields, field)
+	}
+	for field := range after {
+		if _, ok := before[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if !bytes.Equal(before[field], after[field]) {
+			result = append(result, ConfigFieldChange{After: string(after[field]), Before: string(before[field]), Field: field})
+		}
+	}
+	return result
+}
+
+// The items of one section, by code.
+func parseConfigItems(section configSection, table json.RawMessage) (map[string]map[string]json.RawMessage, error) {
+	result := map[string]map[string]json.RawMessage{}
+	if len(table) == 0 {
+		return result, nil
+	}
+	items := []map[string]json.RawMessage{}
+	err := json.Unmarshal(table, &items)
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", section.table, err)
+	}
+	for _, item := range items {
+		var key string
+		err = json.Unmarshal(item[section.keyField], &key)
+		if err != nil {
+			return result, fmt.Errorf("%s item without a %s: %w", section.table, section.keyField, err)
+		}
+		result[key] = item
+	}
+	return result, err
+}
+
+// The normalized tables of a Senzing configuration's G2_CONFIG object, by name.
+func parseConfigTables(configDefinition string) (map[string]json.RawMessage, error) {
+	result := map[string]json.RawMessage{}
+	normalized, err := NormalizeConfig(configDefinition)
+	if err != nil {
+		return result, err
+	}
+	config := map[string]json.RawMessage{}
+	err = json.Unmarshal([]byte(normalized), &config)
+	if err != nil {
+		return result, err
+	}
+	if len(config["G2_CONFIG"]) == 0 || json.Unmarshal(config["G2_CONFIG"], &result) != nil || result == nil {
+		return map[string]json.RawMessage{}, fmt.Errorf("not a Senzing configuration: no G2_CONFIG object")
+	}
+	return result, err
+}
@@ -18,6 +18,30 @@ type SenzingConfig interface {
 	UnregisterObserver(ctx context.Context, observer observer.Observer) error
 }
 
+// ConfigDifference describes one way a Senzing configuration differs from another.
+type ConfigDifference struct {
+	Change  string              `json:"change"`           // One of the ConfigChange* constants.
+	Fields  []ConfigFieldChange `json:"fields,omitempty"` // For ConfigChangeChanged, the fields that differ.
+	Key     string              `json:"key"`              // The item's code, or for ConfigSectionOther, the table.
+	Section string              `json:"section"`          // One of the ConfigSection* constants.
+}
+
+// ConfigFieldChange describes one field of a Senzing configuration item that differs.
+type ConfigFieldChange struct {
+	After  string `json:"after,omitempty"`  // JSON value in the second configuration, if any.
+	Before string `json:"before,omitempty"` // JSON value in the first configuration, if any.
+	Field  string `json:"field"`
+}
+
+// ConfigSummary describes one Senzing configuration registered in SYS_CFG.
+type ConfigSummary struct {
+	Comments        string `json:"comments"`
+	ConfigID        int64  `json:"configId"`
+	CreateTime      string `json:"createTime"`      // SYS_CREATE_DT, as Senzing reports it.
+	DataSourceCount int    `json:"dataSourceCount"` // Includes the datasources of the Senzing configuration template.
+	IsDefault       bool   `json:"isDefault"`
+}
+
 // ----------------------------------------------------------------------------
 // Constants
 // ----------------------------------------------------------------------------
@@ -28,6 +52,23 @@ const ComponentID = 6502
 // Log message prefix.
 const Prefix = "init-database.senzingconfig."
 
+// Changes reported by CompareConfigs.
+const (
+	ConfigChangeAdded   = "added"
+	ConfigChangeChanged = "changed"
+	ConfigChangeRemoved = "removed"
+)
+
+// Sections of a Senzing configuration compared by CompareConfigs.
+// ConfigSectionOther holds the remaining CFG_* tables, compared as a whole.
+const (
+	ConfigSectionAttributes  = "attributes"
+	ConfigSectionDataSources = "datasources"
+	ConfigSectionFeatures    = "features"
+	ConfigSectionOther       = "other"
+	ConfigSectionRules       = "rules"
+)
+
 // ----------------------------------------------------------------------------
 // Variables
 // ----------------------------------------------------------------------------
@@ -39,90 +80,134 @@ var IDMessages = map[int]string{
 	12:   "Exit  " + Prefix + "InitializeSenzing(); senzingConfig.getDependentServices failed; returned (%v).",
 	13:   "Exit  " + Prefix + "InitializeSenzing(); szConfigmgr.GetDefaultConfigID failed; returned (%v).",
 	14:   "Exit  " + Prefix + "InitializeSenzing(); Senzing configuration already exists; returned (%v).",
-	15:   "Exit  " + Prefix + "InitializeSenzing(); szConfig.Create failed; returned (%v).",
+	15:   "Exit  " + Prefix + "InitializeSenzing(); senzingConfig.createConfig failed; returned (%v).",
 	16:   "Exit  " + Prefix + "InitializeSenzing(); senzingConfig.addDatasources failed; returned (%v).",
 	17:   "Exit  " + Prefix + "InitializeSenzing(); szConfig.Save failed; returned (%v).",
 	18:   "Exit  " + Prefix + "InitializeSenzing(); szConfigmgr.AddConfig failed; returned (%v).",
-	19:   "Exit  " + Prefix + "InitializeSenzing(); szConfigmgr.SetDefaultConfigID failed; returned (%v).",
-	20:   "Exit  " + Prefix + "InitializeSenzing(); settingsparser.New failed; returned (%v).",
-	21:   "Exit  " + Prefix + "InitializeSenzing(); settingsparser.GetResourcePath failed; returned (%v).",
-	22:   "Exit  " + Prefix + "InitializeSenzing(); os.Stat failed; returned (%v).",
-	23:   "Exit  " + Prefix + "InitializeSenzing(); copyFile when backing up failed; returned (%v).",
-	24:   "Exit  " + Prefix + "InitializeSenzing(); copyFile when replacing template/szConfig.json failed; returned (%v).",
+	19:   "Exit  " + Prefix + "InitializeSenzing(); senzingConfig.setDefaultConfigID failed; returned (%v).",
+	25:   "Exit  " + Prefix + "InitializeSenzing(); senzingConfig.printPlan failed; returned (%v).",
+	26:   "Exit  " + Prefix + "InitializeSenzing(); dry run; returned (%v).",
+	27:   "Exit  " + Prefix + "InitializeSenzing(); senzingConfig.getLogger failed; returned (%v).",
+	28:   "Exit  " + Prefix + "InitializeSenzing(); senzingConfig.reconcileDataSources failed; returned (%v).",
 	29:   "Exit  " + Prefix + "InitializeSenzing() returned (%v).",
 	30:   "Enter " + Prefix + "RegisterObserver(%s).",
 	31:   "Exit  " + Prefix + "RegisterObserver(%s); json.Marshal failed; returned (%v).",
 	32:   "Exit  " + Prefix + "RegisterObserver(%s); senzingConfig.observers.RegisterObserver failed; returned (%v).",
-	33:   "Exit  " + Prefix + "RegisterObserver(%s); senzingConfig.getDependentServices failed; returned (%v).",
-	34:   "Exit  " + Prefix + "RegisterObserver(%s); szConfig.RegisterObserver failed; returned (%v).",
-	35:   "Exit  " + Prefix + "RegisterObserver(%s); szConfigmgr.RegisterObserver failed; returned (%v).",
 	39:   "Exit  " + Prefix + "RegisterObserver(%s) returned (%v).",
 	40:   "Enter " + Prefix + "SetLogLevel(%s).",
 	41:   "Exit  " + Prefix + "SetLogLevel(%s); json.Marshal failed; returned (%v).",
 	42:   "Exit  " + Prefix + "SetLogLevel(%s); logging.IsValidLogLevelName failed; returned (%v).",
 	43:   "Exit  " + Prefix + "SetLogLevel(%s); senzingConfig.getLogger().SetLogLevel failed; returned (%v).",
-	44:   "Exit  " + Prefix + "SetLogLevel(%s); senzingConfig.getDependentServices failed; returned (%v).",
-	45:   "Exit  " + Prefix + "SetLogLevel(%s); szConfig.SetLogLevel failed; returned (%v).",
-	46:   "Exit  " + Prefix + "SetLogLevel(%s); szConfigmgr.SetLogLevel failed; returned (%v).",
 	49:   "Exit  " + Prefix + "SetLogLevel(%s) returned (%v).",
 	50:   "Enter " + Prefix + "UnregisterObserver(%s).",
 	51:   "Exit  " + Prefix + "UnregisterObserver(%s); json.Marshal failed; returned (%v).",
-	52:   "Exit  " + Prefix + "UnregisterObserver(%s); szConfig.UnregisterObserver failed; returned (%v).",
-	53:   "Exit  " + Prefix + "UnregisterObserver(%s); szConfigmgr.UnregisterObserver failed; returned (%v).",
 	54:   "Exit  " + Prefix + "UnregisterObserver(%s); senzingConfig.observers.UnregisterObserver failed; returned (%v).",
 	59:   "Exit  " + Prefix + "UnregisterObserver(%s) returned (%v).",
 	60:   "Enter " + Prefix + "SetObserverOrigin(%s).",
 	61:   "Exit  " + Prefix + "SetObserverOrigin(%s); json.Marshal failed; returned (%v).",
 	69:   "Exit  " + Prefix + "SetObserverOrigin(%s).",
+	70:   "Enter " + Prefix + "ExportConfig(%d).",
+	71:   "Exit  " + Prefix + "ExportConfig(%d); json.Marshal failed; returned (%v).",
+	72:   "Exit  " + Prefix + "ExportConfig(%d); senzingConfig.getDependentServices failed; returned (%v).",
+	73:   "Exit  " + Prefix + "ExportConfig(%d); szConfigmgr.GetDefaultConfigID failed; returned (%v).",
+	74:   "Exit  " + Prefix + "ExportConfig(%d); no default Senzing configuration; returned (%v).",
+	75:   "Exit  " + Prefix + "ExportConfig(%d); szConfigmgr.GetConfigs failed; returned (%v).",
+	76:   "Exit  " + Prefix + "ExportConfig(%d); Senzing configuration not registered; returned (%v).",
+	77:   "Exit  " + Prefix + "ExportConfig(%d); szConfigmgr.GetConfig failed; returned (%v).",
+	78:   "Exit  " + Prefix + "ExportConfig(%d); senzingConfig.getLogger failed; returned (%v).",
+	79:   "Exit  " + Prefix + "ExportConfig(%d) returned (%v).",
+	80:   "Enter " + Prefix + "ListConfigs().",
+	81:   "Exit  " + Prefix + "ListConfigs(); json.Marshal failed; returned (%v).",
+	82:   "Exit  " + Prefix + "ListConfigs(); senzingConfig.getDependentServices failed; returned (%v).",
+	83:   "Exit  " + Prefix + "ListConfigs(); szConfigmgr.GetDefaultConfigID failed; returned (%v).",
+	84:   "Exit  " + Prefix + "ListConfigs(); szConfigmgr.GetConfigs failed; returned (%v).",
+	85:   "Exit  " + Prefix + "ListConfigs(); szConfigmgr.GetConfig failed; returned (%v).",
+	86:   "Exit  " + Prefix + "ListConfigs(); countDataSources failed; returned (%v).",
+	88:   "Exit  " + Prefix + "ListConfigs(); senzingConfig.getLogger failed; returned (%v).",
+	89:   "Exit  " + Prefix + "ListConfigs() returned (%v).",
+	90:   "Enter " + Prefix + "RollbackConfig(%d).",
+	91:   "Exit  " + Prefix + "RollbackConfig(%d); json.Marshal failed; returned (%v).",
+	92:   "Exit  " + Prefix + "RollbackConfig(%d); senzingConfig.getDependentServices failed; returned (%v).",
+	93:   "Exit  " + Prefix + "RollbackConfig(%d); szConfigmgr.GetConfigs failed; returned (%v).",
+	94:   "Exit  " + Prefix + "RollbackConfig(%d); Senzing configuration not registered; returned (%v).",
+	95:   "Exit  " + Prefix + "RollbackConfig(%d); szConfigmgr.GetDefaultConfigID failed; returned (%v).",
+	96:   "Exit  " + Prefix + "RollbackConfig(%d); senzingConfig.setDefaultConfigID failed; returned (%v).",
+	97:   "Exit  " + Prefix + "RollbackConfig(%d); already the default Senzing configuration; returned (%v).",
+	98:   "Exit  " + Prefix + "RollbackConfig(%d); senzingConfig.getLogger failed; returned (%v).",
+	99:   "Exit  " + Prefix + "RollbackConfig(%d) returned (%v).",
 	1001: Prefix + "InitializeSenzing parameters: %+v",
 	1002: Prefix + "RegisterObserver parameters: %+v",
 	1003: Prefix + "SetLogLevel parameters: %+v",
 	1004: Prefix + "SetObserverOrigin parameters: %+v",
 	1005: Prefix + "UnregisterObserver parameters: %+v",
+	1006: Prefix + "ExportConfig(%d) parameters: %+v",
+	1007: Prefix + "ListConfigs parameters: %+v",
+	1008: Prefix + "RollbackConfig(%d) parameters: %+v",
 	1011: Prefix + "Initialize(); json.Marshal failed; Error: %v.",
 	1012: Prefix + "Initialize(); senzingConfig.getDependentServices failed; Error: %v.",
 	1013: Prefix + "Initialize(); szConfigmgr.GetDefaultConfigID failed; Error: %v.",
-	1014: Prefix + "Initialize(); senzingSchema.InitializeSenzing failed; Error: %v.",
-	1015: Prefix + "Initialize(); szConfig.Create failed; Error: %v.",
+	1015: Prefix + "Initialize(); senzingConfig.createConfig failed; Error: %v.",
 	1016: Prefix + "Initialize(); senzingConfig.addDatasources failed; Error: %v.",
 	1017: Prefix + "Initialize(); szConfig.Save failed; Error: %v.",
 	1018: Prefix + "Initialize(); szConfigmgr.AddConfig failed; Error: %v.",
-	1019: Prefix + "Initialize(); szConfigmgr.SetDefaultConfigID failed; Error: %v.",
-	1020: Prefix + "Initialize(); settingsparser.New failed; Error: %v.",
-	1021: Prefix + "Initialize(); settingsparser.GetResourcePath failed; Error: %v.",
-	1022: Prefix + "Initialize(); os.Stat failed; Error: %v.",
-	1023: Prefix + "Initialize(); copyFile when backing up failed; Error: %v.",
-	1024: Prefix + "Initialize(); copyFile when replacing template/szConfig.json failed; Error: %v.",
+	1019: Prefix + "Initialize(); senzingConfig.setDefaultConfigID failed; Error: %v.",
+	1025: Prefix + "Initialize(); senzingConfig.printPlan failed; Error: %v.",
+	1027: Prefix + "Initialize(); senzingConfig.getLogger failed; Error: %v.",
+	1028: Prefix + "Initialize(); senzingConfig.reconcileDataSources failed; Error: %v.",
 	1031: Prefix + "RegisterObserver(%s); json.Marshal failed; returned (%v).",
 	1032: Prefix + "RegisterObserver(%s); senzingConfig.observers.RegisterObserver failed; returned (%v).",
-	1033: Prefix + "RegisterObserver(%s); senzingConfig.getDependentServices failed; returned (%v).",
-	1034: Prefix + "RegisterObserver(%s); szConfig.RegisterObserver failed; returned (%v).",
-	1035: Prefix + "RegisterObserver(%s); szConfigmgr.RegisterObserver failed; returned (%v).",
 	1041: Prefix + "SetLogLevel(%s); json.Marshal failed; returned (%v).",
 	1042: Prefix + "SetLogLevel(%s); logging.IsValidLogLevelName failed; returned (%v).",
 	1043: Prefix + "SetLogLevel(%s); senzingConfig.getLogger().SetLogLevel failed; returned (%v).",
-	1044: Prefix + "SetLogLevel(%s); senzingConfig.getDependentServices failed; returned (%v).",
-	1045: Prefix + "SetLogLevel(%s); szConfig.SetLogLevel failed; returned (%v).",
-	1046: Prefix + "SetLogLevel(%s); szConfigmgr.SetLogLevel failed; returned (%v).",
 	1051: Prefix + "UnregisterObserver(%s); json.Marshal failed; returned (%v).",
-	1052: Prefix + "UnregisterObserver(%s); szConfig.UnregisterObserver failed; returned (%v).",
-	1053: Prefix + "UnregisterObserver(%s); szConfigmgr.UnregisterObserver failed; returned (%v).",
 	1054: Prefix + "UnregisterObserver(%s); senzingConfig.observers.UnregisterObserver failed; returned (%v).",
+	1061: Prefix + "SetObserverOrigin(%s); json.Marshal failed; returned (%v).",
+	1071: Prefix + "ExportConfig(%d); json.Marshal failed; returned (%v).",
+	1072: Prefix + "ExportConfig(%d); senzingConfig.getDependentServices failed; returned (%v).",
+	1073: Prefix + "ExportConfig(%d); szConfigmgr.GetDefaultConfigID failed; returned (%v).",
+	1074: Prefix + "ExportConfig(%d); no default Senzing configuration; returned (%v).",
+	1075: Prefix + "ExportConfig(%d); szConfigmgr.GetConfigs failed; returned (%v).",
+	1076: Prefix + "ExportConfig(%d); Senzing configuration not registered; returned (%v).",
+	1077: Prefix + "ExportConfig(%d); szConfigmgr.GetConfig failed; returned (%v).",
+	1078: Prefix + "ExportConfig(%d); senzingConfig.getLogger failed; returned (%v).",
+	1081: Prefix + "ListConfigs(); json.Marshal failed; returned (%v).",
+	1082: Prefix + "ListConfigs(); senzingConfig.getDependentServices failed; returned (%v).",
+	1083: Prefix + "ListConfigs(); szConfigmgr.GetDefaultConfigID failed; returned (%v).",
+	1084: Prefix + "ListConfigs(); szConfigmgr.GetConfigs failed; returned (%v).",
+	1085: Prefix + "ListConfigs(); szConfigmgr.GetConfig failed; returned (%v).",
+	1086: Prefix + "ListConfigs(); countDataSources failed; returned (%v).",
+	1088: Prefix + "ListConfigs(); senzingConfig.getLogger failed; returned (%v).",
+	1091: Prefix + "RollbackConfig(%d); json.Marshal failed; returned (%v).",
+	1092: Prefix + "RollbackConfig(%d); senzingConfig.getDependentServices failed; returned (%v).",
+	1093: Prefix + "RollbackConfig(%d); szConfigmgr.GetConfigs failed; returned (%v).",
+	1094: Prefix + "RollbackConfig(%d); Senzing configuration not registered; returned (%v).",
+	1095: Prefix + "RollbackConfig(%d); szConfigmgr.GetDefaultConfigID failed; returned (%v).",
+	1096: Prefix + "RollbackConfig(%d); senzingConfig.setDefaultConfigID failed; returned (%v).",
+	1098: Prefix + "RollbackConfig(%d); senzingConfig.getLogger failed; returned (%v).",
 	2001: "Added Datasource: %s",
 	2002: "No new Senzing configuration created.  One already exists (%d).",
 	2003: "Created Senzing configuration: %d named: %s",
-	2004: "Copied file %s to %s",
-	2005: "%s and %s have same content.  No file manipulation needed.",
-	4001: "When comparing %s and %s, an error occurred. Assuming files not equal.",
+	2006: "Senzing settings name %d databases.  Senzing configuration is stored in %s.",
+	2007: "Datasources of Senzing configuration %d need no changes.",
+	2008: "Created Senzing configuration: %d replacing default %d named: %s",
+	2009: "Deleted Datasource: %s",
+	2010: "Default Senzing configuration changed from %d to %d.",
+	2011: "Senzing configuration %d is already the default.  Nothing changed.",
+	2012: "Imported Senzing configuration from %s",
+	3001: "Senzing configuration template %s does not exist.  Using the copy embedded in init-database.",
+	3002: "Datasources not deleted, because record counts cannot be read through a Senzing gRPC server: %s",
+	3003: "Datasource %s not deleted.  It has %d records in %s.",
 	5001: "File does not exist: %s [SENZING_TOOLS_ENGINE_CONFIGURATION_FILE]",
-	5002: "Could not backup %s to %s",
-	5003: "Could not copy %s to %s [SENZING_TOOLS_ENGINE_CONFIGURATION_FILE]",
 	8001: Prefix + "InitializeSenzing - config exists",
 	8002: Prefix + "InitializeSenzing",
 	8003: Prefix + "RegisterObserver",
 	8004: Prefix + "SetLogLevel",
 	8005: Prefix + "SetObserverOrigin",
 	8006: Prefix + "UnregisterObserver",
+	8007: Prefix + "InitializeSenzing - datasources reconciled",
+	8008: Prefix + "ExportConfig",
+	8009: Prefix + "ListConfigs",
+	8010: Prefix + "RollbackConfig",
 }
 
 // Status strings for specific messages.
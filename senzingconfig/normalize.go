@@ -0,0 +1,55 @@
+package senzingconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Fields of CONFIG_BASE_VERSION that describe the Senzing build that wrote a configuration, not the configuration.
+var volatileConfigFields = []string{
+	"BUILD_DATE",
+	"BUILD_NUMBER",
+	"BUILD_VERSION",
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+NormalizeConfig rewrites a Senzing configuration so configurations from different environments compare cleanly.
+Object keys are sorted and the CONFIG_BASE_VERSION fields set by the Senzing build are removed.
+A normalized configuration is for version control and comparison; import the configuration as exported.
+
+Input
+  - configDefinition: A Senzing configuration JSON.
+
+Output
+  - The normalized Senzing configuration JSON, on one line.
+*/
+func NormalizeConfig(configDefinition string) (string, error) {
+	var config map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(configDefinition))
+	decoder.UseNumber() // Numbers are written back exactly as read.
+	err := decoder.Decode(&config)
+	if err != nil {
+		return "", err
+	}
+	if g2Config, ok := config["G2_CONFIG"].(map[string]interface{}); ok {
+		if baseVersion, ok := g2Config["CONFIG_BASE_VERSION"].(map[string]interface{}); ok {
+			for _, field := range volatileConfigFields {
+				delete(baseVersion, field)
+			}
+		}
+	}
+	normalized := &bytes.Buffer{}
+	encoder := json.NewEncoder(normalized)
+	encoder.SetEscapeHTML(false)
+	err = encoder.Encode(config)
+	return strings.TrimSuffix(normalized.String(), "\n"), err
+}
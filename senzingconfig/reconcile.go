@@ -0,0 +1,199 @@
+package senzingconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/senzing-garage/init-database/redact"
+	"github.com/senzing-garage/init-database/sqlpool"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The datasources of a Senzing configuration, as returned by SzConfig.GetDataSources.
+type configDataSources struct {
+	DataSources []struct {
+		ID   int64  `json:"DSRC_ID"`
+		Code string `json:"DSRC_CODE"`
+	} `json:"DATA_SOURCES"`
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Datasources in the Senzing configuration template.  They are never deleted.
+var templateDataSources = map[string]bool{
+	"SEARCH": true,
+	"TEST":   true,
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+/*
+Bring the datasources of the default Senzing configuration in line with DataSources.
+Missing datasources are added.  With DeleteUnusedDataSources, other datasources without records are deleted.
+A changed configuration is saved and made the default.  The resulting default configuration ID is returned.
+*/
+func (senzingConfig *BasicSenzingConfig) reconcileDataSources(ctx context.Context, szConfig senzing.SzConfig, szConfigManager senzing.SzConfigManager, configID int64) (int64, error) {
+
+	// Load the default configuration.
+
+	configDefinition, err := szConfigManager.GetConfig(ctx, configID)
+	if err != nil {
+		return configID, err
+	}
+	configHandle, err := szConfig.ImportConfig(ctx, configDefinition)
+	if err != nil {
+		return configID, err
+	}
+	dataSourcesJSON, err := szConfig.GetDataSources(ctx, configHandle)
+	if err != nil {
+		return configID, err
+	}
+	current, err := parseDataSources(dataSourcesJSON)
+	if err != nil {
+		return configID, err
+	}
+
+	// Decide what changes.
+
+	missing, unlisted := diffDataSources(current, senzingConfig.DataSources)
+	deleted := []string{}
+	if senzingConfig.DeleteUnusedDataSources && len(unlisted) > 0 {
+		deleted, err = senzingConfig.getUnusedDataSources(ctx, current, unlisted)
+		if err != nil {
+			return configID, err
+		}
+	}
+	if len(missing) == 0 && len(deleted) == 0 {
+		senzingConfig.log(2007, configID)
+		return configID, err
+	}
+
+	// Change, save, and make default.
+
+	for _, dataSource := range missing {
+		_, err = szConfig.AddDataSource(ctx, configHandle, dataSource)
+		if err != nil {
+			return configID, err
+		}
+		senzingConfig.log(2001, dataSource)
+	}
+	for _, dataSource := range deleted {
+		err = szConfig.DeleteDataSource(ctx, configHandle, dataSource)
+		if err != nil {
+			return configID, err
+		}
+		senzingConfig.log(2009, dataSource)
+	}
+	configStr, err := szConfig.ExportConfig(ctx, configHandle)
+	if err != nil {
+		return configID, err
+	}
+	configComments := fmt.Sprintf("Datasources reconciled by %s at %s.", defaultModuleName, time.Now().Format(time.RFC3339Nano))
+	if len(missing) > 0 {
+		configComments += " Added: " + strings.Join(missing, ", ") + "."
+	}
+	if len(deleted) > 0 {
+		configComments += " Deleted: " + strings.Join(deleted, ", ") + "."
+	}
+	newConfigID, err := szConfigManager.AddConfig(ctx, configStr, configComments)
+	if err != nil {
+		return configID, err
+	}
+	err = senzingConfig.setDefaultConfigID(ctx, szConfigManager, configID, newConfigID)
+	if err != nil {
+		return configID, err
+	}
+	senzingConfig.log(2008, newConfigID, configID, configComments)
+	return newConfigID, err
+}
+
+// Of the datasources not listed in DataSources, those having no records.
+func (senzingConfig *BasicSenzingConfig) getUnusedDataSources(ctx context.Context, current map[string]int64, unlisted []string) ([]string, error) {
+	result := []string{}
+	if len(senzingConfig.GrpcTarget) > 0 {
+		senzingConfig.log(3002, strings.Join(unlisted, ", "))
+		return result, nil
+	}
+	databaseURL, err := getTableDatabaseURL(senzingConfig.SenzingSettings, recordTable)
+	if err != nil {
+		return result, err
+	}
+	database, err := sqlpool.Open(ctx, databaseURL)
+	if err != nil {
+		return result, err
+	}
+	defer sqlpool.Close(database, databaseURL)
+	for _, dataSource := range unlisted {
+		var recordCount int64
+		err = database.QueryRowContext(ctx, fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE DSRC_ID = %d", recordTable, current[dataSource])).Scan(&recordCount)
+		if err != nil {
+			return result, err
+		}
+		if recordCount > 0 {
+			senzingConfig.log(3003, dataSource, recordCount, redact.DatabaseURL(databaseURL))
+			continue
+		}
+		result = append(result, dataSource)
+	}
+	return result, err
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Datasource codes, and their IDs, from the JSON returned by SzConfig.GetDataSources.
+func parseDataSources(dataSourcesJSON string) (map[string]int64, error) {
+	result := map[string]int64{}
+	parsed := &configDataSources{}
+	err := json.Unmarshal([]byte(dataSourcesJSON), parsed)
+	if err != nil {
+		return result, err
+	}
+	for _, dataSource := range parsed.DataSources {
+		result[strings.ToUpper(dataSource.Code)] = dataSource.ID
+	}
+	return result, err
+}
+
+/*
+Compare the datasources of a configuration to the datasources wanted.
+Returned are the sorted wanted datasources the configuration lacks, and the sorted datasources the configuration has
+that are not wanted.  Datasources from the Senzing configuration template are always wanted.
+Datasource codes are compared without regard to case, as Senzing stores them in upper case.
+*/
+func diffDataSources(current map[string]int64, wanted []string) ([]string, []string) {
+	missing := []string{}
+	unlisted := []string{}
+	wantedCodes := map[string]bool{}
+	for _, dataSource := range wanted {
+		code := strings.ToUpper(strings.TrimSpace(dataSource))
+		if len(code) == 0 || wantedCodes[code] {
+			continue
+		}
+		wantedCodes[code] = true
+		if _, ok := current[code]; !ok {
+			missing = append(missing, code)
+		}
+	}
+	for code := range current {
+		if !wantedCodes[code] && !templateDataSources[code] {
+			unlisted = append(unlisted, code)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(unlisted)
+	return missing, unlisted
+}
@@ -0,0 +1,82 @@
+package senzingconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The Senzing configurations registered in SYS_CFG, as returned by SzConfigManager.GetConfigs.
+type configRegistry struct {
+	Configs []struct {
+		ConfigComments string `json:"CONFIG_COMMENTS"`
+		ConfigID       int64  `json:"CONFIG_ID"`
+		SysCreateDt    string `json:"SYS_CREATE_DT"`
+	} `json:"CONFIGS"`
+}
+
+// The datasources of a Senzing configuration JSON.
+type configDataSourceTable struct {
+	G2Config *struct {
+		DataSources []json.RawMessage `json:"CFG_DSRC"`
+	} `json:"G2_CONFIG"`
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The number of datasources in a Senzing configuration JSON.
+func countDataSources(configDefinition string) (int, error) {
+	table := &configDataSourceTable{}
+	err := json.Unmarshal([]byte(configDefinition), table)
+	if err != nil {
+		return 0, err
+	}
+	if table.G2Config == nil {
+		return 0, fmt.Errorf("not a Senzing configuration: no G2_CONFIG object")
+	}
+	return len(table.G2Config.DataSources), err
+}
+
+// Whether a Senzing configuration ID appears in the JSON returned by SzConfigManager.GetConfigs.
+func isRegisteredConfig(configsJSON string, configID int64) (bool, error) {
+	summaries, err := parseConfigs(configsJSON)
+	if err != nil {
+		return false, err
+	}
+	for _, summary := range summaries {
+		if summary.ConfigID == configID {
+			return true, err
+		}
+	}
+	return false, err
+}
+
+// The Senzing configurations in the JSON returned by SzConfigManager.GetConfigs, oldest first.
+func parseConfigs(configsJSON string) ([]ConfigSummary, error) {
+	result := []ConfigSummary{}
+	registry := &configRegistry{}
+	err := json.Unmarshal([]byte(configsJSON), registry)
+	if err != nil {
+		return result, err
+	}
+	for _, config := range registry.Configs {
+		result = append(result, ConfigSummary{
+			Comments:   config.ConfigComments,
+			ConfigID:   config.ConfigID,
+			CreateTime: config.SysCreateDt,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].CreateTime != result[j].CreateTime {
+			return result[i].CreateTime < result[j].CreateTime
+		}
+		return result[i].ConfigID < result[j].ConfigID
+	})
+	return result, err
+}
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
 	"time"
 
@@ -17,7 +20,11 @@ import (
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/go-observing/subject"
 	"github.com/senzing-garage/go-sdk-abstract-factory/szfactorycreator"
+	"github.com/senzing-garage/init-database/initerror"
+	"github.com/senzing-garage/init-database/redact"
+	"github.com/senzing-garage/init-database/resources"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-go/szerror"
 	"google.golang.org/grpc"
 )
 
@@ -27,21 +34,29 @@ import (
 
 // BasicSenzingConfig is the default implementation of the SenzingConfig interface.
 type BasicSenzingConfig struct {
-	DataSources           []string          `json:"dataSources,omitempty"`
-	GrpcDialOptions       []grpc.DialOption `json:"grpcDialOptions,omitempty"`
-	GrpcTarget            string            `json:"grpcTarget,omitempty"`
-	SenzingInstanceName   string            `json:"senzingInstanceName,omitempty"`
-	SenzingSettings       string            `json:"senzingSettings,omitempty"`
-	SenzingSettingsFile   string            `json:"senzingSettingsFile,omitempty"`
-	SenzingVerboseLogging int64             `json:"senzingVerboseLogging,omitempty"`
+	DataSources             []string          `json:"dataSources,omitempty"`
+	DeleteUnusedDataSources bool              `json:"deleteUnusedDataSources,omitempty"`
+	DryRun                  bool              `json:"dryRun,omitempty"`
+	DryRunOutput            io.Writer         `json:"-"`
+	GrpcDialOptions         []grpc.DialOption `json:"grpcDialOptions,omitempty"`
+	GrpcTarget              string            `json:"grpcTarget,omitempty"`
+	ReconcileDataSources    bool              `json:"reconcileDataSources,omitempty"`
+	SenzingInstanceName     string            `json:"senzingInstanceName,omitempty"`
+	SenzingSettings         string            `json:"senzingSettings,omitempty"`
+	SenzingSettingsFile     string            `json:"senzingSettingsFile,omitempty"`
+	SenzingVerboseLogging   int64             `json:"senzingVerboseLogging,omitempty"`
 
 	isTrace                    bool
 	logger                     logging.Logging
+	loggerErr                  error
 	logLevel                   string
 	observerOrigin             string
 	observers                  subject.Subject
+	szAbstractFactoryErr       error
 	szAbstractFactorySingleton senzing.SzAbstractFactory
 	szAbstractFactorySyncOnce  sync.Once
+	szConfigErr                error
+	szConfigManagerErr         error
 	szConfigManagerSingleton   senzing.SzConfigManager
 	szConfigManagerSyncOnce    sync.Once
 	szConfigSingleton          senzing.SzConfig
@@ -69,15 +84,15 @@ var defaultModuleName = "init-database"
 // --- Logging ----------------------------------------------------------------
 
 // Get the Logger singleton.
+// If the logger cannot be created, loggerErr records why and a logger with default options is used.
 func (senzingConfig *BasicSenzingConfig) getLogger() logging.Logging {
-	var err error
 	if senzingConfig.logger == nil {
 		options := []interface{}{
 			&logging.OptionCallerSkip{Value: 4},
 		}
-		senzingConfig.logger, err = logging.NewSenzingLogger(ComponentID, IDMessages, options...)
-		if err != nil {
-			panic(err)
+		senzingConfig.logger, senzingConfig.loggerErr = logging.NewSenzingLogger(ComponentID, IDMessages, options...)
+		if senzingConfig.loggerErr != nil {
+			senzingConfig.logger, _ = logging.New()
 		}
 	}
 	return senzingConfig.logger
@@ -106,106 +121,89 @@ func (senzingConfig *BasicSenzingConfig) traceExit(messageNumber int, details ..
 	senzingConfig.getLogger().Log(messageNumber, details...)
 }
 
+// --- Errors -----------------------------------------------------------------
+
+// Classify an error, identifying it by the debug message describing it.
+func wrapError(class error, messageNumber int, err error) error {
+	return initerror.New(class, ComponentID, messageNumber, err)
+}
+
+// The class of an error creating the Senzing configuration.  A missing SenzingSettingsFile has its own class.
+func configFileClass(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return initerror.ErrFileMissing
+	}
+	return initerror.ErrConfigCreate
+}
+
+// The class of an error saving a Senzing configuration as the default.  Losing to another writer of the default has its own class.
+func configSaveClass(err error) error {
+	if errors.Is(err, initerror.ErrConfigExists) || errors.Is(err, szerror.ErrSzReplaceConflict) {
+		return initerror.ErrConfigExists
+	}
+	return initerror.ErrConfigSave
+}
+
 // --- Dependent services -----------------------------------------------------
 
 // Create an abstract factory singleton and return it.
-func (senzingConfig *BasicSenzingConfig) getAbstractFactory(ctx context.Context) senzing.SzAbstractFactory {
-	_ = ctx
+func (senzingConfig *BasicSenzingConfig) getAbstractFactory(ctx context.Context) (senzing.SzAbstractFactory, error) {
 	senzingConfig.szAbstractFactorySyncOnce.Do(func() {
-		if len(senzingConfig.GrpcTarget) == 0 {
-			senzingSettings := senzingConfig.SenzingSettings
-
-			// Handle case of SQLite in-memory database.
-			// TODO:  Refactor to different, reusable, location.
-
-			settingsParser := settingsparser.BasicSettingsParser{
-				Settings: senzingConfig.SenzingSettings,
-			}
+		senzingConfig.szAbstractFactorySingleton, senzingConfig.szAbstractFactoryErr = senzingConfig.createAbstractFactory(ctx)
+	})
+	return senzingConfig.szAbstractFactorySingleton, senzingConfig.szAbstractFactoryErr
+}
 
-			databaseURLs, err := settingsParser.GetDatabaseURLs(ctx)
-			assertNoError(err)
-			if len(databaseURLs) > 1 {
-				panic("Too many databaseURLs")
-			}
-			// databaseURL := databaseURLs[0]
-			// parsedURL, err := url.Parse(databaseURL)
-			// assertNoError(err)
-
-			// if parsedURL.Scheme == "sqlite3" {
-
-			// 	// TODO:  The following if-statement may not be needed.
-			// 	if len(parsedURL.RawQuery) > 0 {
-
-			// 		configPath, err := settingsParser.GetConfigPath(ctx)
-			// 		assertNoError(err)
-			// 		resourcePath, err := settingsParser.GetResourcePath(ctx)
-			// 		assertNoError(err)
-			// 		supportPath, err := settingsParser.GetSupportPath(ctx)
-			// 		assertNoError(err)
-			// 		licenseStringBase64, err := settingsParser.GetLicenseStringBase64(ctx)
-			// 		assertNoError(err)
-
-			// 		szConfiguration := settings.SzConfiguration{
-			// 			Pipeline: settings.SzConfigurationPipeline{
-			// 				ConfigPath:          configPath,
-			// 				LicenseStringBase64: licenseStringBase64,
-			// 				ResourcePath:        resourcePath,
-			// 				SupportPath:         supportPath,
-			// 			},
-			// 			SQL: settings.SzConfigurationSQL{
-			// 				Connection: databaseURL,
-			// 			},
-			// 		}
-
-			// 		buffer := &bytes.Buffer{}
-			// 		jsonEncoder := json.NewEncoder(buffer)
-			// 		jsonEncoder.SetEscapeHTML(false)
-			// 		err = jsonEncoder.Encode(szConfiguration)
-			// 		assertNoError(err)
-
-			// 		senzingSettings = buffer.String()
-
-			// 		// senzingSettingsBytes, err := json.Marshal(szConfiguration, encOpts())
-			// 		// assertNoError(err)
-			// 		// senzingSettings := fmt.Sprintf("%s", senzingSettingsBytes)
-			// 	}
-			// }
-			// fmt.Printf(">>>>> senzingconfig_basic.go getAbstractFactory senzingSettings: %s\n", senzingSettings)
-
-			senzingConfig.szAbstractFactorySingleton, err = szfactorycreator.CreateCoreAbstractFactory(senzingConfig.SenzingInstanceName, senzingSettings, senzingConfig.SenzingVerboseLogging, senzing.SzInitializeWithDefaultConfiguration)
-			if err != nil {
-				panic(err)
-			}
-		} else {
-			grpcConnection, err := grpc.NewClient(senzingConfig.GrpcTarget, senzingConfig.GrpcDialOptions...)
-			if err != nil {
-				panic(err)
-			}
-			senzingConfig.szAbstractFactorySingleton, err = szfactorycreator.CreateGrpcAbstractFactory(grpcConnection)
-			if err != nil {
-				panic(err)
-			}
+// Create an abstract factory for Senzing's core library or, if GrpcTarget is set, for a Senzing gRPC server.
+func (senzingConfig *BasicSenzingConfig) createAbstractFactory(ctx context.Context) (senzing.SzAbstractFactory, error) {
+	if len(senzingConfig.GrpcTarget) > 0 {
+		grpcConnection, err := grpc.NewClient(senzingConfig.GrpcTarget, senzingConfig.GrpcDialOptions...)
+		if err != nil {
+			return nil, err
 		}
-	})
-	return senzingConfig.szAbstractFactorySingleton
+		return szfactorycreator.CreateGrpcAbstractFactory(grpcConnection)
+	}
+	settingsParser := settingsparser.BasicSettingsParser{
+		Settings: senzingConfig.SenzingSettings,
+	}
+	databaseURLs, err := settingsParser.GetDatabaseURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(databaseURLs) > 1 {
+		configDatabaseURL, err := getTableDatabaseURL(senzingConfig.SenzingSettings, configTable)
+		if err != nil {
+			return nil, err
+		}
+		senzingConfig.log(2006, len(databaseURLs), redact.DatabaseURL(configDatabaseURL))
+	}
+	return szfactorycreator.CreateCoreAbstractFactory(senzingConfig.SenzingInstanceName, senzingConfig.SenzingSettings, senzingConfig.SenzingVerboseLogging, senzing.SzInitializeWithDefaultConfiguration)
 }
 
 // Create a SzConfig singleton and return it.
 func (senzingConfig *BasicSenzingConfig) getSzConfig(ctx context.Context) (senzing.SzConfig, error) {
-	var err error
 	senzingConfig.szConfigSyncOnce.Do(func() {
-		senzingConfig.szConfigSingleton, err = senzingConfig.getAbstractFactory(ctx).CreateConfig(ctx)
+		var szAbstractFactory senzing.SzAbstractFactory
+		szAbstractFactory, senzingConfig.szConfigErr = senzingConfig.getAbstractFactory(ctx)
+		if senzingConfig.szConfigErr != nil {
+			return
+		}
+		senzingConfig.szConfigSingleton, senzingConfig.szConfigErr = szAbstractFactory.CreateConfig(ctx)
 	})
-	return senzingConfig.szConfigSingleton, err
+	return senzingConfig.szConfigSingleton, senzingConfig.szConfigErr
 }
 
 // Create a SzConfigManager singleton and return it.
 func (senzingConfig *BasicSenzingConfig) getSzConfigmgr(ctx context.Context) (senzing.SzConfigManager, error) {
-	var err error
 	senzingConfig.szConfigManagerSyncOnce.Do(func() {
-		senzingConfig.szConfigManagerSingleton, err = senzingConfig.getAbstractFactory(ctx).CreateConfigManager(ctx)
+		var szAbstractFactory senzing.SzAbstractFactory
+		szAbstractFactory, senzingConfig.szConfigManagerErr = senzingConfig.getAbstractFactory(ctx)
+		if senzingConfig.szConfigManagerErr != nil {
+			return
+		}
+		senzingConfig.szConfigManagerSingleton, senzingConfig.szConfigManagerErr = szAbstractFactory.CreateConfigManager(ctx)
 	})
-	return senzingConfig.szConfigManagerSingleton, err
+	return senzingConfig.szConfigManagerSingleton, senzingConfig.szConfigManagerErr
 }
 
 // Get dependent services: SzConfig, SzConfigManager
@@ -236,116 +234,286 @@ func (senzingConfig *BasicSenzingConfig) addDatasources(ctx context.Context, szC
 	return err
 }
 
-func (senzingConfig *BasicSenzingConfig) copyFile(sourceFilename string, targetFilename string) error {
-	sourceFilename = filepath.Clean(sourceFilename)
-	sourceFile, err := os.Open(sourceFilename)
+/*
+Create an in-memory Senzing configuration.
+A SenzingSettingsFile other than the template in the Senzing resource path is imported as a complete configuration.
+Otherwise the configuration starts from the template in the Senzing resource path or, if missing, the embedded copy.
+*/
+func (senzingConfig *BasicSenzingConfig) createConfig(ctx context.Context, szConfig senzing.SzConfig) (uintptr, error) {
+	resourcePath, err := senzingConfig.getResourcePath(ctx)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	defer func() {
-		if err := sourceFile.Close(); err != nil {
-			senzingConfig.log(9999, sourceFilename, err)
-		}
-	}()
-	targetFilename = filepath.Clean(targetFilename)
-	targetFile, err := os.Create(targetFilename)
-	if err != nil {
-		return err
+	if senzingConfig.importsConfigFile(resourcePath) {
+		return senzingConfig.importConfigFile(ctx, szConfig)
 	}
-	defer func() {
-		if err := targetFile.Close(); err != nil {
-			senzingConfig.log(9999, targetFilename, err)
-		}
-	}()
-	_, err = io.Copy(targetFile, sourceFile)
+	if !resources.IsMissing(resourcePath, resources.ConfigTemplate) {
+		return szConfig.CreateConfig(ctx)
+	}
+	configDefinition, err := resources.ReadFile(resources.ConfigTemplate)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	senzingConfig.log(2004, sourceFilename, targetFilename)
-	return err
+	senzingConfig.log(3001, filepath.Join(resourcePath, resources.ConfigTemplate))
+	return szConfig.ImportConfig(ctx, string(configDefinition))
 }
 
-func (senzingConfig *BasicSenzingConfig) filesAreEqual(sourceFilename string, targetFilename string) bool {
-	var (
-		chunkSize        = 64000
-		shortCircuitExit bool
-	)
+// Import SenzingSettingsFile through the Senzing SDK.  The Senzing resource path is not changed.
+func (senzingConfig *BasicSenzingConfig) importConfigFile(ctx context.Context, szConfig senzing.SzConfig) (uintptr, error) {
+	configDefinition, err := readConfigFile(senzingConfig.SenzingSettingsFile)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			senzingConfig.log(5001, senzingConfig.SenzingSettingsFile)
+		}
+		return 0, err
+	}
+	configHandle, err := szConfig.ImportConfig(ctx, configDefinition)
+	if err != nil {
+		return 0, fmt.Errorf("Senzing cannot import %s: %w", senzingConfig.SenzingSettingsFile, err)
+	}
 
-	// If file sizes differ, then files differ.
+	// Reading the datasources back shows the Senzing SDK understands the imported configuration.
 
-	sourceStat, err := os.Stat(sourceFilename)
+	_, err = szConfig.GetDataSources(ctx, configHandle)
 	if err != nil {
-		return false
+		return 0, fmt.Errorf("Senzing cannot read the configuration imported from %s: %w", senzingConfig.SenzingSettingsFile, err)
 	}
-	targetStat, err := os.Stat(targetFilename)
+	senzingConfig.log(2012, senzingConfig.SenzingSettingsFile)
+	return configHandle, err
+}
+
+// Whether SenzingSettingsFile names a configuration to import, rather than the template in the Senzing resource path.
+func (senzingConfig *BasicSenzingConfig) importsConfigFile(resourcePath string) bool {
+	return len(senzingConfig.SenzingSettingsFile) > 0 && filepath.Clean(senzingConfig.SenzingSettingsFile) != filepath.Join(resourcePath, resources.ConfigTemplate)
+}
+
+// The Senzing resource path from the Senzing settings.
+func (senzingConfig *BasicSenzingConfig) getResourcePath(ctx context.Context) (string, error) {
+	parsedJSON, err := settingsparser.New(senzingConfig.SenzingSettings)
 	if err != nil {
-		return false
+		return "", err
 	}
+	return parsedJSON.GetResourcePath(ctx)
+}
 
-	if sourceStat.Size() != targetStat.Size() {
-		return false
+// Where dry run output is written.
+func (senzingConfig *BasicSenzingConfig) getDryRunOutput() io.Writer {
+	if senzingConfig.DryRunOutput == nil {
+		return os.Stdout
 	}
+	return senzingConfig.DryRunOutput
+}
 
-	// Final check: If file contents differ, then files differ.
-
-	sourceFilename = filepath.Clean(sourceFilename)
-	sourceFile, err := os.Open(sourceFilename)
+// Describe, for a dry run, the Senzing configuration that would be created.
+func (senzingConfig *BasicSenzingConfig) printPlan(ctx context.Context) error {
+	resourcePath, err := senzingConfig.getResourcePath(ctx)
 	if err != nil {
-		shortCircuitExit = true
+		return err
 	}
-	defer func() {
-		if err := sourceFile.Close(); err != nil {
-			senzingConfig.log(9999, sourceFilename, err)
+	templateFilename := filepath.Join(resourcePath, resources.ConfigTemplate)
+	buffer := &bytes.Buffer{}
+	fmt.Fprintf(buffer, "Senzing configuration: created only if the repository has no default configuration\n")
+	switch {
+	case senzingConfig.importsConfigFile(resourcePath):
+		_, err = readConfigFile(senzingConfig.SenzingSettingsFile)
+		if err != nil {
+			fmt.Fprintf(buffer, "  Configuration: %s (cannot be imported: %v)\n", senzingConfig.SenzingSettingsFile, err)
+		} else {
+			fmt.Fprintf(buffer, "  Configuration: %s (imported as is; %s is not changed)\n", senzingConfig.SenzingSettingsFile, templateFilename)
 		}
-	}()
+	case resources.IsMissing(resourcePath, resources.ConfigTemplate):
+		fmt.Fprintf(buffer, "  Template: copy embedded in init-database (%s does not exist)\n", templateFilename)
+	default:
+		fmt.Fprintf(buffer, "  Template: %s\n", templateFilename)
+	}
+	fmt.Fprintf(buffer, "  Datasources that would be added: %d\n", len(senzingConfig.DataSources))
+	for _, datasource := range senzingConfig.DataSources {
+		fmt.Fprintf(buffer, "    %s\n", datasource)
+	}
+	if senzingConfig.ReconcileDataSources {
+		fmt.Fprintf(buffer, "  Existing default configuration: missing datasources would be added\n")
+		if senzingConfig.DeleteUnusedDataSources {
+			fmt.Fprintf(buffer, "  Existing default configuration: unlisted datasources without records would be deleted\n")
+		}
+	}
+	if len(senzingConfig.GrpcTarget) == 0 {
+		configDatabaseURL, err := getTableDatabaseURL(senzingConfig.SenzingSettings, configTable)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(buffer, "  Database holding %s: %s\n", configTable, redact.DatabaseURL(configDatabaseURL))
+	}
+	_, err = senzingConfig.getDryRunOutput().Write(buffer.Bytes())
+	return err
+}
 
-	targetFilename = filepath.Clean(targetFilename)
-	targetFile, err := os.Open(targetFilename)
+/*
+Make a Senzing configuration the default, then confirm a Senzing engine created from the same settings uses it.
+With multi-database settings, this shows SYS_CFG was written to the database the engine reads it from.
+A previousConfigID other than 0 is replaced only if it is still the default.
+*/
+func (senzingConfig *BasicSenzingConfig) setDefaultConfigID(ctx context.Context, szConfigManager senzing.SzConfigManager, previousConfigID int64, configID int64) error {
+	var err error
+	if previousConfigID == 0 {
+		err = szConfigManager.SetDefaultConfigID(ctx, configID)
+	} else {
+		err = szConfigManager.ReplaceDefaultConfigID(ctx, previousConfigID, configID)
+	}
+	if err != nil {
+		return err
+	}
+	defaultConfigID, err := szConfigManager.GetDefaultConfigID(ctx)
 	if err != nil {
-		shortCircuitExit = true
+		return err
 	}
-	defer func() {
-		if err := targetFile.Close(); err != nil {
-			senzingConfig.log(9999, targetFilename, err)
-		}
-	}()
+	if defaultConfigID != configID {
+		return fmt.Errorf("default Senzing configuration is %d, not %d: %w", defaultConfigID, configID, initerror.ErrConfigExists)
+	}
+	if len(senzingConfig.GrpcTarget) > 0 {
+		return err // A Senzing gRPC server's engine loads the new configuration when it reinitializes.
+	}
+
+	// Ask the engine which configuration it uses.  An engine already running in this process is reinitialized.
 
-	if shortCircuitExit {
-		return false
+	szAbstractFactory, err := senzingConfig.getAbstractFactory(ctx)
+	if err != nil {
+		return err
+	}
+	szEngine, err := szAbstractFactory.CreateEngine(ctx)
+	if err != nil {
+		return err
+	}
+	activeConfigID, err := szEngine.GetActiveConfigID(ctx)
+	if err != nil {
+		return err
+	}
+	if activeConfigID != configID {
+		err = szAbstractFactory.Reinitialize(ctx, configID)
+		if err != nil {
+			return fmt.Errorf("Senzing engine cannot load configuration %d from %s: %w", configID, configTable, err)
+		}
 	}
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The ExportConfig method returns the JSON of a Senzing configuration registered in the Senzing repository.
+
+Input
+  - ctx: A context to control lifecycle.
+  - configID: The identifier of the Senzing configuration.  0 means the default configuration.
+
+Output
+  - The Senzing configuration JSON, as stored.
+*/
+func (senzingConfig *BasicSenzingConfig) ExportConfig(ctx context.Context, configID int64) (string, error) {
+	var err error
+	var result string
+
+	// Prolog.
 
-	for {
-		sourceBytes := make([]byte, chunkSize)
-		_, sourceError := sourceFile.Read(sourceBytes)
+	debugMessageNumber := 0
+	traceExitMessageNumber := 79
+	if senzingConfig.getLogger().IsDebug() {
 
-		targetBytes := make([]byte, chunkSize)
-		_, targetError := targetFile.Read(targetBytes)
+		// If DEBUG, log error exit.
 
-		if sourceError != nil || targetError != nil {
-			switch {
-			case sourceError == io.EOF && targetError == io.EOF:
-				return true
-			case sourceError == io.EOF || targetError == io.EOF:
-				return false
-			default:
-				senzingConfig.log(4001, sourceFilename, targetFilename, sourceError, targetError)
+		defer func() {
+			if debugMessageNumber > 0 {
+				senzingConfig.debug(debugMessageNumber, err)
 			}
+		}()
+
+		// If TRACE, Log on entry/exit.
+
+		if senzingConfig.getLogger().IsTrace() {
+			entryTime := time.Now()
+			senzingConfig.traceEntry(70, configID)
+			defer func() {
+				senzingConfig.traceExit(traceExitMessageNumber, configID, err, time.Since(entryTime))
+			}()
 		}
-		if !bytes.Equal(sourceBytes, targetBytes) {
-			return false
+
+		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
+
+		asJSON, err := redact.Marshal(senzingConfig)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 71, 1071
+			return result, err
 		}
+		senzingConfig.log(1006, configID, string(asJSON))
+	}
+
+	// Exporting with a logger that could not be created is an error, as for InitializeSenzing.
+
+	if senzingConfig.loggerErr != nil {
+		traceExitMessageNumber, debugMessageNumber = 78, 1078
+		return result, wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, senzingConfig.loggerErr)
 	}
-}
 
-func assertNoError(err error) {
+	// Create Senzing objects.
+
+	_, szConfigManager, err := senzingConfig.getDependentServices(ctx)
 	if err != nil {
-		panic(err)
+		traceExitMessageNumber, debugMessageNumber = 72, 1072
+		return result, wrapError(initerror.ErrConnect, debugMessageNumber, err)
 	}
-}
 
-// ----------------------------------------------------------------------------
-// Interface methods
-// ----------------------------------------------------------------------------
+	// Resolve and check the configuration ID.
+
+	if configID == 0 {
+		configID, err = szConfigManager.GetDefaultConfigID(ctx)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 73, 1073
+			return result, wrapError(initerror.ErrConnect, debugMessageNumber, err)
+		}
+		if configID == 0 {
+			err = fmt.Errorf("the Senzing repository has no default configuration")
+			traceExitMessageNumber, debugMessageNumber = 74, 1074
+			return result, wrapError(initerror.ErrConfigNotFound, debugMessageNumber, err)
+		}
+	}
+	configsJSON, err := szConfigManager.GetConfigs(ctx)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 75, 1075
+		return result, wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+	registered, err := isRegisteredConfig(configsJSON, configID)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 75, 1075
+		return result, wrapError(initerror.ErrConfigParse, debugMessageNumber, err)
+	}
+	if !registered {
+		err = fmt.Errorf("Senzing configuration %d is not in %s", configID, configTable)
+		traceExitMessageNumber, debugMessageNumber = 76, 1076
+		return result, wrapError(initerror.ErrConfigNotFound, debugMessageNumber, err)
+	}
+
+	// Fetch the configuration.
+
+	result, err = szConfigManager.GetConfig(ctx, configID)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 77, 1077
+		return result, wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+
+	// Notify observers.
+
+	if senzingConfig.observers != nil {
+		go func() {
+			details := map[string]string{
+				"configID": strconv.FormatInt(configID, 10),
+			}
+			notifier.Notify(ctx, senzingConfig.observers, senzingConfig.observerOrigin, ComponentID, 8008, err, details)
+		}()
+	}
+
+	return result, err
+}
 
 /*
 The InitializeSenzing method adds the Senzing default configuration to databases.
@@ -381,12 +549,32 @@ func (senzingConfig *BasicSenzingConfig) InitializeSenzing(ctx context.Context)
 
 		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
 
-		asJSON, err := json.Marshal(senzingConfig)
+		asJSON, err := redact.Marshal(senzingConfig)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 11, 1011
 			return err
 		}
-		senzingConfig.log(1001, senzingConfig, string(asJSON))
+		senzingConfig.log(1001, string(asJSON))
+	}
+
+	// A logger that could not be created is an error.
+
+	if senzingConfig.loggerErr != nil {
+		traceExitMessageNumber, debugMessageNumber = 27, 1027
+		return wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, senzingConfig.loggerErr)
+	}
+
+	// In a dry run, describe the configuration instead of creating it.
+	// The Senzing SDK is not started, because the database may not have a schema yet.
+
+	if senzingConfig.DryRun {
+		err = senzingConfig.printPlan(ctx)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 25, 1025
+			return wrapError(initerror.ErrSettings, debugMessageNumber, err)
+		}
+		traceExitMessageNumber, debugMessageNumber = 26, 0 // debugMessageNumber=0 because it's not an error.
+		return err
 	}
 
 	// Create Senzing objects.
@@ -394,14 +582,29 @@ func (senzingConfig *BasicSenzingConfig) InitializeSenzing(ctx context.Context)
 	szConfig, szConfigManager, err := senzingConfig.getDependentServices(ctx)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 12, 1012
-		return err
+		return wrapError(initerror.ErrConnect, debugMessageNumber, err)
 	}
 
-	// Determine if configuration already exists. If so, return.
+	// Determine if configuration already exists. If so, reconcile its datasources if requested, then return.
 
 	configID, err = szConfigManager.GetDefaultConfigID(ctx)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 13, 1013
+		return wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+	if configID != 0 && senzingConfig.ReconcileDataSources {
+		configID, err = senzingConfig.reconcileDataSources(ctx, szConfig, szConfigManager, configID)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 28, 1028
+			return wrapError(configSaveClass(err), debugMessageNumber, err)
+		}
+		if senzingConfig.observers != nil {
+			go func() {
+				details := map[string]string{}
+				notifier.Notify(ctx, senzingConfig.observers, senzingConfig.observerOrigin, ComponentID, 8007, err, details)
+			}()
+		}
+		traceExitMessageNumber, debugMessageNumber = 14, 0 // debugMessageNumber=0 because it's not an error.
 		return err
 	}
 	if configID != 0 {
@@ -416,72 +619,13 @@ func (senzingConfig *BasicSenzingConfig) InitializeSenzing(ctx context.Context)
 		return err
 	}
 
-	// If engine configuration file specified, swap it in.
-
-	if len(senzingConfig.SenzingSettingsFile) > 0 {
-		parsedJSON, err := settingsparser.New(senzingConfig.SenzingSettings)
-		if err != nil {
-			traceExitMessageNumber, debugMessageNumber = 20, 1020
-			return err
-		}
-		resourcePath, err := parsedJSON.GetResourcePath(ctx)
-		if err != nil {
-			traceExitMessageNumber, debugMessageNumber = 21, 1021
-			return err
-		}
+	// Create a fresh Senzing configuration, either imported from SenzingSettingsFile or from the configuration template.
+	// If the Senzing resource path lacks the configuration template, start from the copy embedded in init-database.
 
-		// Compare file names.
-
-		sourceFilename := senzingConfig.SenzingSettingsFile
-		targetFilename := fmt.Sprintf("%s/templates/g2config.json", resourcePath)
-		if sourceFilename != targetFilename {
-
-			// Verify source file exists.
-
-			_, err := os.Stat(sourceFilename)
-			if err != nil {
-				senzingConfig.log(5001, sourceFilename, err)
-				traceExitMessageNumber, debugMessageNumber = 22, 1022
-				return err
-			}
-
-			// Determine if target file needs to be replaced.
-
-			if senzingConfig.filesAreEqual(sourceFilename, targetFilename) {
-				senzingConfig.log(2005, sourceFilename, targetFilename)
-			} else {
-
-				// If target file exists, back it up.
-
-				_, err = os.Stat(targetFilename)
-				if err == nil {
-					backupFilename := fmt.Sprintf("%s.%d", targetFilename, time.Now().Unix())
-					err = senzingConfig.copyFile(targetFilename, backupFilename)
-					if err != nil {
-						senzingConfig.log(5002, targetFilename, backupFilename, err)
-						traceExitMessageNumber, debugMessageNumber = 23, 1023
-						return err
-					}
-				}
-
-				// Copy source file to target to "fake out" Senzing's SzEngine.Create().
-
-				err = senzingConfig.copyFile(sourceFilename, targetFilename)
-				if err != nil {
-					senzingConfig.log(5003, sourceFilename, targetFilename, err)
-					traceExitMessageNumber, debugMessageNumber = 24, 1024
-					return err
-				}
-			}
-		}
-	}
-
-	// Create a fresh Senzing configuration.
-
-	configHandle, err := szConfig.CreateConfig(ctx)
+	configHandle, err := senzingConfig.createConfig(ctx, szConfig)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 15, 1015
-		return err
+		return wrapError(configFileClass(err), debugMessageNumber, err)
 	}
 
 	// If requested, add DataSources to fresh Senzing configuration.
@@ -490,7 +634,7 @@ func (senzingConfig *BasicSenzingConfig) InitializeSenzing(ctx context.Context)
 		err = senzingConfig.addDatasources(ctx, szConfig, configHandle)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 16, 1016
-			return err
+			return wrapError(initerror.ErrConfigCreate, debugMessageNumber, err)
 		}
 	}
 
@@ -499,7 +643,7 @@ func (senzingConfig *BasicSenzingConfig) InitializeSenzing(ctx context.Context)
 	configStr, err := szConfig.ExportConfig(ctx, configHandle)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 17, 1017
-		return err
+		return wrapError(initerror.ErrConfigCreate, debugMessageNumber, err)
 	}
 
 	// Persist the Senzing configuration to the Senzing repository and set as default configuration.
@@ -508,12 +652,12 @@ func (senzingConfig *BasicSenzingConfig) InitializeSenzing(ctx context.Context)
 	configID, err = szConfigManager.AddConfig(ctx, configStr, configComments)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 18, 1018
-		return err
+		return wrapError(initerror.ErrConfigSave, debugMessageNumber, err)
 	}
-	err = szConfigManager.SetDefaultConfigID(ctx, configID)
+	err = senzingConfig.setDefaultConfigID(ctx, szConfigManager, 0, configID)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 19, 1019
-		return err
+		return wrapError(configSaveClass(err), debugMessageNumber, err)
 	}
 
 	// Notify observers.
@@ -529,6 +673,115 @@ func (senzingConfig *BasicSenzingConfig) InitializeSenzing(ctx context.Context)
 	return err
 }
 
+/*
+The ListConfigs method describes the Senzing configurations registered in the Senzing repository.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - The Senzing configurations, oldest first.
+*/
+func (senzingConfig *BasicSenzingConfig) ListConfigs(ctx context.Context) ([]ConfigSummary, error) {
+	var err error
+	result := []ConfigSummary{}
+
+	// Prolog.
+
+	debugMessageNumber := 0
+	traceExitMessageNumber := 89
+	if senzingConfig.getLogger().IsDebug() {
+
+		// If DEBUG, log error exit.
+
+		defer func() {
+			if debugMessageNumber > 0 {
+				senzingConfig.debug(debugMessageNumber, err)
+			}
+		}()
+
+		// If TRACE, Log on entry/exit.
+
+		if senzingConfig.getLogger().IsTrace() {
+			entryTime := time.Now()
+			senzingConfig.traceEntry(80)
+			defer func() {
+				senzingConfig.traceExit(traceExitMessageNumber, err, time.Since(entryTime))
+			}()
+		}
+
+		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
+
+		asJSON, err := redact.Marshal(senzingConfig)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 81, 1081
+			return result, err
+		}
+		senzingConfig.log(1007, string(asJSON))
+	}
+
+	// Listing with a logger that could not be created is an error.
+
+	if senzingConfig.loggerErr != nil {
+		traceExitMessageNumber, debugMessageNumber = 88, 1088
+		return result, wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, senzingConfig.loggerErr)
+	}
+
+	// Create Senzing objects.
+
+	_, szConfigManager, err := senzingConfig.getDependentServices(ctx)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 82, 1082
+		return result, wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+
+	// List the registered configurations.
+
+	defaultConfigID, err := szConfigManager.GetDefaultConfigID(ctx)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 83, 1083
+		return result, wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+	configsJSON, err := szConfigManager.GetConfigs(ctx)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 84, 1084
+		return result, wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+	result, err = parseConfigs(configsJSON)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 84, 1084
+		return result, wrapError(initerror.ErrConfigParse, debugMessageNumber, err)
+	}
+
+	// Count each configuration's datasources.
+
+	for index := range result {
+		result[index].IsDefault = (result[index].ConfigID == defaultConfigID)
+		var configDefinition string
+		configDefinition, err = szConfigManager.GetConfig(ctx, result[index].ConfigID)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 85, 1085
+			return result, wrapError(initerror.ErrConnect, debugMessageNumber, err)
+		}
+		result[index].DataSourceCount, err = countDataSources(configDefinition)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 86, 1086
+			return result, wrapError(initerror.ErrConfigParse, debugMessageNumber, err)
+		}
+	}
+
+	// Notify observers.
+
+	if senzingConfig.observers != nil {
+		go func() {
+			details := map[string]string{}
+			notifier.Notify(ctx, senzingConfig.observers, senzingConfig.observerOrigin, ComponentID, 8009, err, details)
+		}()
+	}
+
+	return result, err
+}
+
 /*
 The RegisterObserver method adds the observer to the list of observers notified.
 
@@ -569,12 +822,12 @@ func (senzingConfig *BasicSenzingConfig) RegisterObserver(ctx context.Context, o
 
 		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
 
-		asJSON, err := json.Marshal(senzingConfig)
+		asJSON, err := redact.Marshal(senzingConfig)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 31, 1031
 			return err
 		}
-		senzingConfig.log(1002, senzingConfig, string(asJSON))
+		senzingConfig.log(1002, string(asJSON))
 	}
 
 	// Create empty list of observers.
@@ -583,6 +836,14 @@ func (senzingConfig *BasicSenzingConfig) RegisterObserver(ctx context.Context, o
 		senzingConfig.observers = &subject.SimpleSubject{}
 	}
 
+	// Register observer with senzingConfig.
+
+	err = senzingConfig.observers.RegisterObserver(ctx, observer)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 32, 1032
+		return err
+	}
+
 	// Notify observers.
 
 	go func() {
@@ -595,6 +856,118 @@ func (senzingConfig *BasicSenzingConfig) RegisterObserver(ctx context.Context, o
 	return err
 }
 
+/*
+The RollbackConfig method makes an earlier Senzing configuration, registered in the Senzing repository, the default.
+
+Input
+  - ctx: A context to control lifecycle.
+  - configID: The identifier of the Senzing configuration to make the default.
+*/
+func (senzingConfig *BasicSenzingConfig) RollbackConfig(ctx context.Context, configID int64) error {
+	var err error
+	var previousConfigID int64
+
+	// Prolog.
+
+	debugMessageNumber := 0
+	traceExitMessageNumber := 99
+	if senzingConfig.getLogger().IsDebug() {
+
+		// If DEBUG, log error exit.
+
+		defer func() {
+			if debugMessageNumber > 0 {
+				senzingConfig.debug(debugMessageNumber, err)
+			}
+		}()
+
+		// If TRACE, Log on entry/exit.
+
+		if senzingConfig.getLogger().IsTrace() {
+			entryTime := time.Now()
+			senzingConfig.traceEntry(90, configID)
+			defer func() {
+				senzingConfig.traceExit(traceExitMessageNumber, configID, err, time.Since(entryTime))
+			}()
+		}
+
+		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
+
+		asJSON, err := redact.Marshal(senzingConfig)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 91, 1091
+			return err
+		}
+		senzingConfig.log(1008, configID, string(asJSON))
+	}
+
+	// Changing the default configuration with a logger that could not be created is an error.
+
+	if senzingConfig.loggerErr != nil {
+		traceExitMessageNumber, debugMessageNumber = 98, 1098
+		return wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, senzingConfig.loggerErr)
+	}
+
+	// Create Senzing objects.
+
+	_, szConfigManager, err := senzingConfig.getDependentServices(ctx)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 92, 1092
+		return wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+
+	// Only a configuration registered in SYS_CFG may become the default.
+
+	configsJSON, err := szConfigManager.GetConfigs(ctx)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 93, 1093
+		return wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+	registered, err := isRegisteredConfig(configsJSON, configID)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 93, 1093
+		return wrapError(initerror.ErrConfigParse, debugMessageNumber, err)
+	}
+	if !registered {
+		err = fmt.Errorf("Senzing configuration %d is not in %s", configID, configTable)
+		traceExitMessageNumber, debugMessageNumber = 94, 1094
+		return wrapError(initerror.ErrConfigNotFound, debugMessageNumber, err)
+	}
+
+	// Change the default configuration, unless it is already the one requested.
+
+	previousConfigID, err = szConfigManager.GetDefaultConfigID(ctx)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 95, 1095
+		return wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+	if previousConfigID == configID {
+		senzingConfig.log(2011, configID)
+		traceExitMessageNumber, debugMessageNumber = 97, 0 // debugMessageNumber=0 because it's not an error.
+		return err
+	}
+	err = senzingConfig.setDefaultConfigID(ctx, szConfigManager, previousConfigID, configID)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 96, 1096
+		return wrapError(configSaveClass(err), debugMessageNumber, err)
+	}
+	senzingConfig.log(2010, previousConfigID, configID)
+
+	// Notify observers.
+
+	if senzingConfig.observers != nil {
+		go func() {
+			details := map[string]string{
+				"configID":         strconv.FormatInt(configID, 10),
+				"previousConfigID": strconv.FormatInt(previousConfigID, 10),
+			}
+			notifier.Notify(ctx, senzingConfig.observers, senzingConfig.observerOrigin, ComponentID, 8010, err, details)
+		}()
+	}
+
+	return err
+}
+
 /*
 The SetLogLevel method sets the level of logging.
 
@@ -631,19 +1004,19 @@ func (senzingConfig *BasicSenzingConfig) SetLogLevel(ctx context.Context, logLev
 
 		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
 
-		asJSON, err := json.Marshal(senzingConfig)
+		asJSON, err := redact.Marshal(senzingConfig)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 41, 1041
 			return err
 		}
-		senzingConfig.log(1003, senzingConfig, string(asJSON))
+		senzingConfig.log(1003, string(asJSON))
 	}
 
 	// Verify value of logLevelName.
 
 	if !logging.IsValidLogLevelName(logLevelName) {
 		traceExitMessageNumber, debugMessageNumber = 42, 1042
-		return fmt.Errorf("invalid error level: %s", logLevelName)
+		return wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, fmt.Errorf("invalid error level: %s", logLevelName))
 	}
 
 	// Set senzingConfig log level.
@@ -652,7 +1025,7 @@ func (senzingConfig *BasicSenzingConfig) SetLogLevel(ctx context.Context, logLev
 	err = senzingConfig.getLogger().SetLogLevel(logLevelName)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 43, 1043
-		return err
+		return wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, err)
 	}
 	senzingConfig.isTrace = (logLevelName == logging.LevelTraceName)
 
@@ -706,12 +1079,12 @@ func (senzingConfig *BasicSenzingConfig) SetObserverOrigin(ctx context.Context,
 
 		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
 
-		asJSON, err := json.Marshal(senzingConfig)
+		asJSON, err := redact.Marshal(senzingConfig)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 61, 1061
 			return
 		}
-		senzingConfig.log(1004, senzingConfig, string(asJSON))
+		senzingConfig.log(1004, string(asJSON))
 	}
 
 	// Notify observers.
@@ -767,12 +1140,12 @@ func (senzingConfig *BasicSenzingConfig) UnregisterObserver(ctx context.Context,
 
 		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
 
-		asJSON, err := json.Marshal(senzingConfig)
+		asJSON, err := redact.Marshal(senzingConfig)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 51, 1051
 			return err
 		}
-		senzingConfig.log(1005, senzingConfig, string(asJSON))
+		senzingConfig.log(1005, string(asJSON))
 	}
 
 	// Remove observer from this service.
@@ -801,3 +1174,25 @@ func (senzingConfig *BasicSenzingConfig) UnregisterObserver(ctx context.Context,
 
 	return err
 }
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The contents of a Senzing configuration file, after checking it holds a G2_CONFIG object.
+func readConfigFile(filename string) (string, error) {
+	configBytes, err := os.ReadFile(filepath.Clean(filename))
+	if err != nil {
+		return "", err
+	}
+	var configJSON map[string]json.RawMessage
+	err = json.Unmarshal(configBytes, &configJSON)
+	if err != nil {
+		return "", fmt.Errorf("%s is not a Senzing configuration: %w", filename, err)
+	}
+	var g2Config map[string]json.RawMessage
+	if len(configJSON["G2_CONFIG"]) == 0 || json.Unmarshal(configJSON["G2_CONFIG"], &g2Config) != nil || g2Config == nil {
+		return "", fmt.Errorf("%s is not a Senzing configuration: no G2_CONFIG object", filename)
+	}
+	return string(configBytes), err
+}
@@ -5,6 +5,7 @@ package senzingconfig
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/senzing-garage/go-helpers/settings"
 	"github.com/senzing-garage/go-logging/logging"
@@ -58,6 +59,28 @@ func ExampleBasicSenzingConfig_InitializeSenzing() {
 	// Output:
 }
 
+func ExampleBasicSenzingConfig_ExportConfig() {
+	// For more information, visit https://github.com/senzing-garage/init-database/blob/main/senzingconfig/senzingconfig_examples_test.go
+	ctx := context.TODO()
+	senzingSettings, err := settings.BuildSimpleSettingsUsingEnvVars()
+	if err != nil {
+		fmt.Println(err)
+	}
+	senzingConfig := &BasicSenzingConfig{
+		SenzingSettings: senzingSettings,
+	}
+	configDefinition, err := senzingConfig.ExportConfig(ctx, 0)
+	if err != nil {
+		fmt.Println(err)
+	}
+	normalized, err := NormalizeConfig(configDefinition)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(strings.HasPrefix(normalized, `{"G2_CONFIG":{`))
+	// Output: true
+}
+
 func ExampleBasicSenzingConfig_RegisterObserver() {
 	// For more information, visit https://github.com/senzing-garage/init-database/blob/main/senzingconfig/senzingconfig_examples_test.go
 	ctx := context.TODO()
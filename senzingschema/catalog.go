@@ -0,0 +1,241 @@
+package senzingschema
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The tables and indexes that currently exist in a database.
+// Names in the connection's default schema are keyed "NAME"; names in a named schema are keyed "SCHEMA.NAME".
+type databaseCatalog struct {
+	indexes map[string]bool
+	tables  map[string]bool
+}
+
+// How much of a Senzing schema already exists in a database.
+const (
+	schemaStateEmpty = iota
+	schemaStatePartial
+	schemaStateComplete
+)
+
+// The statements needed to bring a database up to the Senzing schema.
+type schemaPlan struct {
+	Execute []sqlStatement
+	Skip    []sqlStatement
+	State   int
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Per database scheme, queries that list the tables and indexes in the connection's default schema.
+var catalogQueries = map[string]struct {
+	indexes string
+	tables  string
+}{
+	"mssql": {
+		indexes: "SELECT i.name FROM sys.indexes i JOIN sys.tables t ON i.object_id = t.object_id WHERE i.name IS NOT NULL AND SCHEMA_NAME(t.schema_id) = SCHEMA_NAME()",
+		tables:  "SELECT name FROM sys.tables WHERE SCHEMA_NAME(schema_id) = SCHEMA_NAME()",
+	},
+	"mysql": {
+		indexes: "SELECT DISTINCT index_name FROM information_schema.statistics WHERE table_schema = DATABASE()",
+		tables:  "SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE()",
+	},
+	"oci": {
+		indexes: "SELECT index_name FROM user_indexes",
+		tables:  "SELECT table_name FROM user_tables",
+	},
+	"postgresql": {
+		indexes: "SELECT indexname FROM pg_indexes WHERE schemaname = current_schema()",
+		tables:  "SELECT table_name FROM information_schema.tables WHERE table_schema = current_schema()",
+	},
+	"sqlite3": {
+		indexes: "SELECT name FROM sqlite_master WHERE type = 'index'",
+		tables:  "SELECT name FROM sqlite_master WHERE type = 'table'",
+	},
+}
+
+// Per database scheme, queries that list the tables and indexes in the schema given as the only parameter.
+// SQLite is absent: its schemas are attached databases, each with its own sqlite_master.
+var schemaCatalogQueries = map[string]struct {
+	indexes string
+	tables  string
+}{
+	"mssql": {
+		indexes: "SELECT i.name FROM sys.indexes i JOIN sys.tables t ON i.object_id = t.object_id WHERE i.name IS NOT NULL AND UPPER(SCHEMA_NAME(t.schema_id)) = @p1",
+		tables:  "SELECT name FROM sys.tables WHERE UPPER(SCHEMA_NAME(schema_id)) = @p1",
+	},
+	"mysql": {
+		indexes: "SELECT DISTINCT index_name FROM information_schema.statistics WHERE UPPER(table_schema) = ?",
+		tables:  "SELECT table_name FROM information_schema.tables WHERE UPPER(table_schema) = ?",
+	},
+	"oci": {
+		indexes: "SELECT index_name FROM all_indexes WHERE UPPER(owner) = :1",
+		tables:  "SELECT table_name FROM all_tables WHERE UPPER(owner) = :1",
+	},
+	"postgresql": {
+		indexes: "SELECT indexname FROM pg_indexes WHERE upper(schemaname) = $1",
+		tables:  "SELECT table_name FROM information_schema.tables WHERE upper(table_schema) = $1",
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Inspect the live database for existing tables and indexes.
+// Besides the default schema, every schema that qualifies one of the statements is inspected.
+func getCatalog(ctx context.Context, database *sql.DB, scheme string, statements []sqlStatement) (*databaseCatalog, error) {
+	var err error
+	queries, ok := catalogQueries[scheme]
+	if !ok {
+		return nil, fmt.Errorf("cannot inspect catalog for database scheme: %s", scheme)
+	}
+	result := &databaseCatalog{}
+	result.tables, err = queryNames(ctx, database, queries.tables)
+	if err != nil {
+		return nil, err
+	}
+	result.indexes, err = queryNames(ctx, database, queries.indexes)
+	if err != nil {
+		return nil, err
+	}
+	inspected := map[string]bool{}
+	for _, statement := range statements {
+		schema := statement.Schema
+		if len(schema) == 0 || inspected[schema] {
+			continue
+		}
+		inspected[schema] = true
+		var tables, indexes map[string]bool
+		tables, indexes, err = querySchemaNames(ctx, database, scheme, schema)
+		if err != nil {
+			return nil, err
+		}
+		for name := range tables {
+			result.tables[qualifiedName(schema, name)] = true
+		}
+		for name := range indexes {
+			result.indexes[qualifiedName(schema, name)] = true
+		}
+	}
+	return result, err
+}
+
+// Compare the statements of a schema file with the catalog and decide which statements are needed.
+//
+//   - CREATE TABLE and CREATE INDEX are skipped when the object already exists.
+//   - ALTER TABLE and INSERT are sent only for tables created by this plan.
+//   - Other statements are sent only when the database holds none of the schema's tables.
+func planSchema(statements []sqlStatement, catalog *databaseCatalog) schemaPlan {
+	result := schemaPlan{}
+	existingTableCount := 0
+	missingObjectCount := 0
+	for _, statement := range statements {
+		switch statement.Kind {
+		case statementKindCreateTable:
+			if catalog.tables[qualifiedName(statement.Schema, statement.Object)] {
+				existingTableCount++
+			} else {
+				missingObjectCount++
+			}
+		case statementKindCreateIndex:
+			if !catalog.indexes[qualifiedName(statement.Schema, statement.Object)] {
+				missingObjectCount++
+			}
+		}
+	}
+
+	switch {
+	case missingObjectCount == 0:
+		result.State = schemaStateComplete
+	case existingTableCount == 0:
+		result.State = schemaStateEmpty
+	default:
+		result.State = schemaStatePartial
+	}
+
+	createdTables := map[string]bool{}
+	for _, statement := range statements {
+		var isNeeded bool
+		switch statement.Kind {
+		case statementKindCreateTable:
+			isNeeded = !catalog.tables[qualifiedName(statement.Schema, statement.Object)]
+			if isNeeded {
+				createdTables[qualifiedName(statement.Schema, statement.Object)] = true
+			}
+		case statementKindCreateIndex:
+			isNeeded = !catalog.indexes[qualifiedName(statement.Schema, statement.Object)]
+		case statementKindAlterTable, statementKindInsert:
+			isNeeded = createdTables[qualifiedName(statement.Schema, statement.Table)]
+		default:
+			isNeeded = (result.State == schemaStateEmpty)
+		}
+		if isNeeded {
+			result.Execute = append(result.Execute, statement)
+		} else {
+			result.Skip = append(result.Skip, statement)
+		}
+	}
+	return result
+}
+
+// The key of a name in a databaseCatalog.
+func qualifiedName(schema string, name string) string {
+	if len(schema) == 0 {
+		return name
+	}
+	return schema + "." + name
+}
+
+// The names of the tables and indexes in one schema.
+func querySchemaNames(ctx context.Context, database *sql.DB, scheme string, schema string) (map[string]bool, map[string]bool, error) {
+	tablesQuery, indexesQuery := "", ""
+	args := []any{}
+	if scheme == "sqlite3" {
+		// An attached database's catalog cannot be named by a parameter, so quote it as an identifier.
+		master := `"` + strings.ReplaceAll(schema, `"`, `""`) + `".sqlite_master`
+		tablesQuery = "SELECT name FROM " + master + " WHERE type = 'table'"
+		indexesQuery = "SELECT name FROM " + master + " WHERE type = 'index'"
+	} else {
+		queries, ok := schemaCatalogQueries[scheme]
+		if !ok {
+			return nil, nil, fmt.Errorf("cannot inspect catalog for database scheme: %s", scheme)
+		}
+		tablesQuery, indexesQuery = queries.tables, queries.indexes
+		args = append(args, schema)
+	}
+	tables, err := queryNames(ctx, database, tablesQuery, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	indexes, err := queryNames(ctx, database, indexesQuery, args...)
+	return tables, indexes, err
+}
+
+// Run a query returning one column of names and collect the names in upper case.
+func queryNames(ctx context.Context, database *sql.DB, query string, args ...any) (map[string]bool, error) {
+	result := map[string]bool{}
+	rows, err := database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			return result, err
+		}
+		result[normalizeObjectName(name)] = true
+	}
+	return result, rows.Err()
+}
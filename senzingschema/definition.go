@@ -0,0 +1,203 @@
+package senzingschema
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A column of a table.
+type columnDefinition struct {
+	Name string
+	Type string // Base type in upper case, without length or precision.  Example: VARCHAR
+}
+
+// An index of a table, other than the primary key.
+type indexDefinition struct {
+	Columns []string
+	Table   string
+	Unique  bool
+}
+
+// A table, its columns and its primary key.
+type tableDefinition struct {
+	Columns    []columnDefinition
+	PrimaryKey []string
+}
+
+// The tables and indexes of a schema, whether declared by a SQL file or found in a database.
+type schemaDefinition struct {
+	Indexes map[string]*indexDefinition
+	Tables  map[string]*tableDefinition
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var (
+	regexpIndexColumns      = regexp.MustCompile(`(?i)\sON\s+[^\s(]+\s*\(([^)]*)\)`)
+	regexpInlinePrimaryKey  = regexp.MustCompile(`(?i)\sPRIMARY\s+KEY\b`)
+	regexpPrimaryKey        = regexp.MustCompile(`(?i)PRIMARY\s+KEY\s*(?:(?:NON)?CLUSTERED\s*)?\(([^)]*)\)`)
+	regexpTypeLength        = regexp.MustCompile(`\s*\(.*$`)
+	regexpUniqueCreateIndex = regexp.MustCompile(`(?i)^\s*CREATE\s+UNIQUE\s`)
+)
+
+// Names databases report for types that are declared with a different name.
+var typeAliases = map[string]string{
+	"CHARACTER VARYING":           "VARCHAR",
+	"CHARACTER":                   "CHAR",
+	"INT":                         "INTEGER",
+	"TIMESTAMP WITHOUT TIME ZONE": "TIMESTAMP",
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Build the schema a SQL file declares from its CREATE TABLE, ALTER TABLE and CREATE INDEX statements.
+func declaredSchema(statements []sqlStatement) *schemaDefinition {
+	result := newSchemaDefinition()
+	for _, statement := range statements {
+		switch statement.Kind {
+		case statementKindCreateTable:
+			result.Tables[statement.Object] = parseTableDefinition(statement.SQL)
+		case statementKindAlterTable:
+			table, ok := result.Tables[statement.Table]
+			if !ok {
+				continue
+			}
+			if match := regexpPrimaryKey.FindStringSubmatch(statement.SQL); match != nil {
+				table.PrimaryKey = splitColumnNames(match[1])
+			}
+		case statementKindCreateIndex:
+			columns := ""
+			if match := regexpIndexColumns.FindStringSubmatch(statement.SQL); match != nil {
+				columns = match[1]
+			}
+			result.Indexes[statement.Object] = &indexDefinition{
+				Columns: splitColumnNames(columns),
+				Table:   statement.Table,
+				Unique:  regexpUniqueCreateIndex.MatchString(statement.SQL),
+			}
+		}
+	}
+	return result
+}
+
+// An empty schemaDefinition.
+func newSchemaDefinition() *schemaDefinition {
+	return &schemaDefinition{
+		Indexes: map[string]*indexDefinition{},
+		Tables:  map[string]*tableDefinition{},
+	}
+}
+
+// Reduce a type to a comparable name.  Example: "character varying(40)" becomes "VARCHAR".
+func normalizeType(dataType string) string {
+	result := strings.ToUpper(strings.TrimSpace(regexpTypeLength.ReplaceAllString(dataType, "")))
+	if alias, ok := typeAliases[result]; ok {
+		return alias
+	}
+	return result
+}
+
+// The text between the first "(" and its matching ")".
+func parenthesizedBody(sqlText string) (string, bool) {
+	start := strings.Index(sqlText, "(")
+	if start < 0 {
+		return "", false
+	}
+	depth := 0
+	for index := start; index < len(sqlText); index++ {
+		switch sqlText[index] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return sqlText[start+1 : index], true
+			}
+		}
+	}
+	return "", false
+}
+
+// Parse the columns and inline primary key of a CREATE TABLE statement.
+func parseTableDefinition(sqlText string) *tableDefinition {
+	result := &tableDefinition{}
+	body, ok := parenthesizedBody(sqlText)
+	if !ok {
+		return result
+	}
+	for _, element := range splitTopLevel(body) {
+		element = strings.TrimSpace(element)
+		upperElement := strings.ToUpper(element)
+		if strings.HasPrefix(upperElement, "PRIMARY KEY") || strings.HasPrefix(upperElement, "CONSTRAINT") {
+			if match := regexpPrimaryKey.FindStringSubmatch(element); match != nil {
+				result.PrimaryKey = splitColumnNames(match[1])
+			}
+			continue
+		}
+		fields := strings.Fields(element)
+		if len(fields) < 2 {
+			continue
+		}
+		result.Columns = append(result.Columns, columnDefinition{
+			Name: normalizeObjectName(fields[0]),
+			Type: normalizeType(fields[1]),
+		})
+		if match := regexpInlinePrimaryKey.FindStringIndex(element); match != nil {
+			result.PrimaryKey = []string{normalizeObjectName(fields[0])}
+		}
+	}
+	return result
+}
+
+// Split "A, b ,C" into normalized column names.
+func splitColumnNames(columns string) []string {
+	result := []string{}
+	for _, column := range strings.Split(columns, ",") {
+		fields := strings.Fields(column)
+		if len(fields) == 0 {
+			continue
+		}
+		result = append(result, normalizeObjectName(fields[0]))
+	}
+	return result
+}
+
+// Split on commas that are not inside parentheses.
+func splitTopLevel(text string) []string {
+	result := []string{}
+	depth := 0
+	start := 0
+	for index, character := range text {
+		switch character {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				result = append(result, text[start:index])
+				start = index + 1
+			}
+		}
+	}
+	return append(result, text[start:])
+}
+
+// Sorted keys of a map.
+func sortedNames[V any](names map[string]V) []string {
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
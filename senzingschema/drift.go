@@ -0,0 +1,223 @@
+package senzingschema
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Per database scheme, queries that describe the schema visible to the connection.
+//   - columns: table name, column name, data type.
+//   - primaryKeys: table name, column name; ordered by table and column position.
+//   - indexes: index name, table name, column name, 1 if unique; ordered by index and column position.  Primary keys are excluded.
+var introspectionQueries = map[string]struct {
+	columns     string
+	indexes     string
+	primaryKeys string
+}{
+	"mssql": {
+		columns: "SELECT TABLE_NAME, COLUMN_NAME, DATA_TYPE FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_SCHEMA = SCHEMA_NAME()",
+		indexes: "SELECT i.name, t.name, c.name, CASE WHEN i.is_unique = 1 THEN 1 ELSE 0 END FROM sys.indexes i JOIN sys.tables t ON t.object_id = i.object_id JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id " +
+			"WHERE i.is_primary_key = 0 AND i.name IS NOT NULL AND ic.is_included_column = 0 AND t.schema_id = SCHEMA_ID() ORDER BY i.name, ic.key_ordinal",
+		primaryKeys: "SELECT tc.TABLE_NAME, kcu.COLUMN_NAME FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu ON kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME AND kcu.TABLE_SCHEMA = tc.TABLE_SCHEMA " +
+			"WHERE tc.CONSTRAINT_TYPE = 'PRIMARY KEY' AND tc.TABLE_SCHEMA = SCHEMA_NAME() ORDER BY tc.TABLE_NAME, kcu.ORDINAL_POSITION",
+	},
+	"mysql": {
+		columns:     "SELECT table_name, column_name, data_type FROM information_schema.columns WHERE table_schema = DATABASE()",
+		indexes:     "SELECT index_name, table_name, column_name, CASE WHEN non_unique = 0 THEN 1 ELSE 0 END FROM information_schema.statistics WHERE table_schema = DATABASE() AND index_name <> 'PRIMARY' ORDER BY index_name, seq_in_index",
+		primaryKeys: "SELECT table_name, column_name FROM information_schema.key_column_usage WHERE table_schema = DATABASE() AND constraint_name = 'PRIMARY' ORDER BY table_name, ordinal_position",
+	},
+	"oci": {
+		columns: "SELECT table_name, column_name, data_type FROM user_tab_columns",
+		indexes: "SELECT i.index_name, i.table_name, ic.column_name, CASE WHEN i.uniqueness = 'UNIQUE' THEN 1 ELSE 0 END FROM user_indexes i JOIN user_ind_columns ic ON ic.index_name = i.index_name " +
+			"WHERE i.index_name NOT IN (SELECT index_name FROM user_constraints WHERE constraint_type = 'P' AND index_name IS NOT NULL) ORDER BY i.index_name, ic.column_position",
+		primaryKeys: "SELECT c.table_name, cc.column_name FROM user_constraints c JOIN user_cons_columns cc ON cc.constraint_name = c.constraint_name WHERE c.constraint_type = 'P' ORDER BY c.table_name, cc.position",
+	},
+	"postgresql": {
+		columns: "SELECT table_name, column_name, data_type FROM information_schema.columns WHERE table_schema = current_schema()",
+		indexes: "SELECT i.relname, t.relname, a.attname, CASE WHEN ix.indisunique THEN 1 ELSE 0 END FROM pg_index ix JOIN pg_class i ON i.oid = ix.indexrelid JOIN pg_class t ON t.oid = ix.indrelid JOIN pg_namespace n ON n.oid = t.relnamespace " +
+			"JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ordinality) ON true JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum " +
+			"WHERE n.nspname = current_schema() AND NOT ix.indisprimary ORDER BY i.relname, k.ordinality",
+		primaryKeys: "SELECT tc.table_name, kcu.column_name FROM information_schema.table_constraints tc JOIN information_schema.key_column_usage kcu ON kcu.constraint_name = tc.constraint_name AND kcu.table_schema = tc.table_schema " +
+			"WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_schema = current_schema() ORDER BY tc.table_name, kcu.ordinal_position",
+	},
+	"sqlite3": {
+		columns:     "SELECT m.name, p.name, p.type FROM sqlite_master m JOIN pragma_table_info(m.name) p WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%'",
+		indexes:     "SELECT il.name, m.name, ii.name, il.\"unique\" FROM sqlite_master m JOIN pragma_index_list(m.name) il JOIN pragma_index_info(il.name) ii WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%' AND il.origin = 'c' ORDER BY il.name, ii.seqno",
+		primaryKeys: "SELECT m.name, p.name FROM sqlite_master m JOIN pragma_table_info(m.name) p WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%' AND p.pk > 0 ORDER BY m.name, p.pk",
+	},
+}
+
+// Tables init-database itself creates, which are never reported as extra.
+var ownTables = map[string]bool{
+	migrationTable: true,
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Compare the schema found in a database with the schema a SQL file declares.
+func compareSchemas(databaseURL string, declared *schemaDefinition, actual *schemaDefinition) []SchemaDifference {
+	result := []SchemaDifference{}
+	add := func(kind string, object string, problem string, expected string, actualValue string) {
+		result = append(result, SchemaDifference{
+			Actual:   actualValue,
+			Database: databaseURL,
+			Expected: expected,
+			Kind:     kind,
+			Object:   object,
+			Problem:  problem,
+		})
+	}
+
+	// Tables, columns and primary keys.
+
+	for _, tableName := range sortedNames(declared.Tables) {
+		declaredTable := declared.Tables[tableName]
+		actualTable, ok := actual.Tables[tableName]
+		if !ok {
+			add(DifferenceKindTable, tableName, DifferenceProblemMissing, "", "")
+			continue
+		}
+		actualColumns := map[string]string{}
+		for _, column := range actualTable.Columns {
+			actualColumns[column.Name] = column.Type
+		}
+		declaredColumns := map[string]bool{}
+		for _, column := range declaredTable.Columns {
+			declaredColumns[column.Name] = true
+			actualType, ok := actualColumns[column.Name]
+			switch {
+			case !ok:
+				add(DifferenceKindColumn, tableName+"."+column.Name, DifferenceProblemMissing, column.Type, "")
+			case actualType != column.Type:
+				add(DifferenceKindColumn, tableName+"."+column.Name, DifferenceProblemMismatched, column.Type, actualType)
+			}
+		}
+		for _, column := range actualTable.Columns {
+			if !declaredColumns[column.Name] {
+				add(DifferenceKindColumn, tableName+"."+column.Name, DifferenceProblemExtra, "", column.Type)
+			}
+		}
+		expectedKey := strings.Join(declaredTable.PrimaryKey, ",")
+		actualKey := strings.Join(actualTable.PrimaryKey, ",")
+		switch {
+		case expectedKey == actualKey:
+		case len(actualKey) == 0:
+			add(DifferenceKindPrimaryKey, tableName, DifferenceProblemMissing, expectedKey, "")
+		case len(expectedKey) == 0:
+			add(DifferenceKindPrimaryKey, tableName, DifferenceProblemExtra, "", actualKey)
+		default:
+			add(DifferenceKindPrimaryKey, tableName, DifferenceProblemMismatched, expectedKey, actualKey)
+		}
+	}
+	for _, tableName := range sortedNames(actual.Tables) {
+		if _, ok := declared.Tables[tableName]; !ok && !ownTables[tableName] {
+			add(DifferenceKindTable, tableName, DifferenceProblemExtra, "", "")
+		}
+	}
+
+	// Indexes.
+
+	for _, indexName := range sortedNames(declared.Indexes) {
+		declaredIndex := declared.Indexes[indexName]
+		actualIndex, ok := actual.Indexes[indexName]
+		if !ok {
+			add(DifferenceKindIndex, indexName, DifferenceProblemMissing, describeIndex(declaredIndex), "")
+			continue
+		}
+		if describeIndex(declaredIndex) != describeIndex(actualIndex) {
+			add(DifferenceKindIndex, indexName, DifferenceProblemMismatched, describeIndex(declaredIndex), describeIndex(actualIndex))
+		}
+	}
+	for _, indexName := range sortedNames(actual.Indexes) {
+		if _, ok := declared.Indexes[indexName]; ok || ownTables[actual.Indexes[indexName].Table] {
+			continue
+		}
+		add(DifferenceKindIndex, indexName, DifferenceProblemExtra, "", describeIndex(actual.Indexes[indexName]))
+	}
+	return result
+}
+
+// A comparable description of an index.  Example: "UNIQUE LIB_FEAT(FEAT_HASH,FTYPE_ID)".
+func describeIndex(index *indexDefinition) string {
+	result := fmt.Sprintf("%s(%s)", index.Table, strings.Join(index.Columns, ","))
+	if index.Unique {
+		result = "UNIQUE " + result
+	}
+	return result
+}
+
+// Describe the tables, columns, primary keys and indexes found in a database.
+func getSchemaDefinition(ctx context.Context, database *sql.DB, scheme string) (*schemaDefinition, error) {
+	queries, ok := introspectionQueries[scheme]
+	if !ok {
+		return nil, fmt.Errorf("cannot inspect schema for database scheme: %s", scheme)
+	}
+	result := newSchemaDefinition()
+	table := func(name string) *tableDefinition {
+		name = normalizeObjectName(name)
+		if _, ok := result.Tables[name]; !ok {
+			result.Tables[name] = &tableDefinition{}
+		}
+		return result.Tables[name]
+	}
+	err := queryRows(ctx, database, queries.columns, func(values []string) {
+		aTable := table(values[0])
+		aTable.Columns = append(aTable.Columns, columnDefinition{
+			Name: normalizeObjectName(values[1]),
+			Type: normalizeType(values[2]),
+		})
+	}, 3)
+	if err != nil {
+		return nil, err
+	}
+	err = queryRows(ctx, database, queries.primaryKeys, func(values []string) {
+		aTable := table(values[0])
+		aTable.PrimaryKey = append(aTable.PrimaryKey, normalizeObjectName(values[1]))
+	}, 2)
+	if err != nil {
+		return nil, err
+	}
+	err = queryRows(ctx, database, queries.indexes, func(values []string) {
+		indexName := normalizeObjectName(values[0])
+		index, ok := result.Indexes[indexName]
+		if !ok {
+			index = &indexDefinition{
+				Table:  normalizeObjectName(values[1]),
+				Unique: values[3] == "1",
+			}
+			result.Indexes[indexName] = index
+		}
+		index.Columns = append(index.Columns, normalizeObjectName(values[2]))
+	}, 4)
+	return result, err
+}
+
+// Run a query and hand each row, as strings, to a function.
+func queryRows(ctx context.Context, database *sql.DB, query string, handleRow func(values []string), columnCount int) error {
+	rows, err := database.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		values := make([]string, columnCount)
+		pointers := make([]interface{}, columnCount)
+		for index := range values {
+			pointers[index] = &values[index]
+		}
+		err = rows.Scan(pointers...)
+		if err != nil {
+			return err
+		}
+		handleRow(values)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,115 @@
+package senzingschema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Privileges the runtime role needs on every Senzing table.  Senzing only reads and writes rows.
+const runtimePrivileges = "SELECT, INSERT, UPDATE, DELETE"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Per database scheme, a query counting roles with a given name.
+var roleExistsSQL = map[string]string{
+	"mssql":      "SELECT COUNT(*) FROM sys.database_principals WHERE name = @p1",
+	"mysql":      "SELECT COUNT(*) FROM mysql.user WHERE user = ?",
+	"oci":        "SELECT COUNT(*) FROM all_users WHERE username = :1",
+	"postgresql": "SELECT COUNT(*) FROM pg_roles WHERE rolname = $1",
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The statements that create a login role with a password.
+func createRoleStatements(scheme string, roleName string, password string) ([]string, error) {
+	switch scheme {
+	case "mssql":
+		return []string{
+			fmt.Sprintf("IF NOT EXISTS (SELECT 1 FROM sys.server_principals WHERE name = N%s) CREATE LOGIN %s WITH PASSWORD = N%s", quoteLiteral(roleName), grantee(scheme, roleName), quoteLiteral(password)),
+			fmt.Sprintf("CREATE USER %s FOR LOGIN %s", grantee(scheme, roleName), grantee(scheme, roleName)),
+		}, nil
+	case "mysql":
+		return []string{
+			fmt.Sprintf("CREATE USER %s IDENTIFIED BY %s", grantee(scheme, roleName), quoteMysqlLiteral(password)),
+		}, nil
+	case "oci":
+		if strings.Contains(password, `"`) {
+			return nil, fmt.Errorf("an Oracle password cannot contain a double quote")
+		}
+		return []string{
+			fmt.Sprintf(`CREATE USER %s IDENTIFIED BY "%s"`, grantee(scheme, roleName), password),
+			fmt.Sprintf("GRANT CREATE SESSION TO %s", grantee(scheme, roleName)),
+		}, nil
+	case "postgresql":
+		return []string{
+			fmt.Sprintf("CREATE ROLE %s LOGIN PASSWORD %s", grantee(scheme, roleName), quoteLiteral(password)),
+		}, nil
+	default:
+		return nil, fmt.Errorf("cannot create role for database scheme: %s", scheme)
+	}
+}
+
+// The statements that grant a role DML access to tables.
+func grantStatements(scheme string, roleName string, tables []string) []string {
+	result := []string{}
+	for _, table := range tables {
+		result = append(result, fmt.Sprintf("GRANT %s ON %s TO %s", runtimePrivileges, table, grantee(scheme, roleName)))
+	}
+	return result
+}
+
+// The statement that lets a role find objects in a PostgreSQL schema.
+func grantSchemaStatement(schemaName string, roleName string) string {
+	return fmt.Sprintf("GRANT USAGE ON SCHEMA %s TO %s", schemaName, grantee("postgresql", roleName))
+}
+
+// How a role is named in CREATE and GRANT statements.
+func grantee(scheme string, roleName string) string {
+	switch scheme {
+	case "mssql":
+		return "[" + strings.ReplaceAll(roleName, "]", "]]") + "]"
+	case "mysql":
+		return quoteMysqlLiteral(roleName) + "@'%'"
+	case "oci":
+		return `"` + strings.ReplaceAll(strings.ToUpper(roleName), `"`, `""`) + `"`
+	default:
+		return `"` + strings.ReplaceAll(roleName, `"`, `""`) + `"`
+	}
+}
+
+// The name a role is stored under, for roleExistsSQL.
+func storedRoleName(scheme string, roleName string) string {
+	if scheme == "oci" {
+		return strings.ToUpper(roleName)
+	}
+	return roleName
+}
+
+// The names of the tables a SQL file creates.
+func tableNames(statements []sqlStatement) []string {
+	result := []string{}
+	for _, statement := range statements {
+		if statement.Kind == statementKindCreateTable {
+			result = append(result, statement.Object)
+		}
+	}
+	return result
+}
+
+// A SQL string literal.
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// A MySQL string literal.  MySQL also treats backslash as an escape character.
+func quoteMysqlLiteral(value string) string {
+	return quoteLiteral(strings.ReplaceAll(value, `\`, `\\`))
+}
@@ -18,6 +18,16 @@ type SenzingSchema interface {
 	UnregisterObserver(ctx context.Context, observer observer.Observer) error
 }
 
+// SchemaDifference describes one way a database differs from the schema its SQL file declares.
+type SchemaDifference struct {
+	Actual   string `json:"actual,omitempty"`   // What the database has, if anything.
+	Database string `json:"database"`           // Redacted database URL.
+	Expected string `json:"expected,omitempty"` // What the SQL file declares, if anything.
+	Kind     string `json:"kind"`               // One of the DifferenceKind* constants.
+	Object   string `json:"object"`             // Table, TABLE.COLUMN, or index name.
+	Problem  string `json:"problem"`            // One of the DifferenceProblem* constants.
+}
+
 // ----------------------------------------------------------------------------
 // Constants
 // ----------------------------------------------------------------------------
@@ -28,6 +38,30 @@ const ComponentID = 6503
 // Log message prefix.
 const Prefix = "init-database.senzingconfig."
 
+// Kinds of objects compared by VerifySchema.
+const (
+	DifferenceKindColumn     = "column"
+	DifferenceKindIndex      = "index"
+	DifferenceKindPrimaryKey = "primaryKey"
+	DifferenceKindTable      = "table"
+)
+
+// Problems reported by VerifySchema.
+const (
+	DifferenceProblemExtra      = "extra"
+	DifferenceProblemMismatched = "mismatched"
+	DifferenceProblemMissing    = "missing"
+)
+
+// Names of the SQLVariables set by init-database's command line options.
+// SQL files refer to them as, for example, {{.Schema}}.
+const (
+	SQLVariableIndexTablespace = "IndexTablespace"
+	SQLVariableSchema          = "Schema"
+	SQLVariableTableOptions    = "TableOptions"
+	SQLVariableTablespace      = "Tablespace"
+)
+
 // ----------------------------------------------------------------------------
 // Variables
 // ----------------------------------------------------------------------------
@@ -40,6 +74,7 @@ var IDMessages = map[int]string{
 	13:   "Exit  " + Prefix + "InitializeSenzing(); parser.GetResourcePath failed; returned (%v).",
 	14:   "Exit  " + Prefix + "InitializeSenzing(); parser.GetDatabaseUrls failed; returned (%v).",
 	15:   "Exit  " + Prefix + "InitializeSenzing(); senzingSchema.processDatabase failed; returned (%v).",
+	16:   "Exit  " + Prefix + "InitializeSenzing(); senzingSchema.getLogger failed; returned (%v).",
 	19:   "Exit  " + Prefix + "InitializeSenzing() returned (%v).",
 	20:   "Enter " + Prefix + "RegisterObserver(%s).",
 	21:   "Exit  " + Prefix + "RegisterObserver(%s); json.Marshal failed; returned (%v).",
@@ -57,23 +92,88 @@ var IDMessages = map[int]string{
 	50:   "Enter " + Prefix + "SetObserverOrigin(%s).",
 	51:   "Exit  " + Prefix + "SetObserverOrigin(%s); json.Marshal failed; returned (%v).",
 	59:   "Exit  " + Prefix + "SetObserverOrigin(%s).",
+	60:   "Enter " + Prefix + "Migrate().",
+	61:   "Exit  " + Prefix + "Migrate(); json.Marshal failed; returned (%v).",
+	62:   "Exit  " + Prefix + "Migrate(); settingsparser.New failed; returned (%v).",
+	63:   "Exit  " + Prefix + "Migrate(); parser.GetResourcePath failed; returned (%v).",
+	64:   "Exit  " + Prefix + "Migrate(); parser.GetDatabaseUrls failed; returned (%v).",
+	65:   "Exit  " + Prefix + "Migrate(); senzingSchema.migrateDatabase failed; returned (%v).",
+	66:   "Exit  " + Prefix + "Migrate(); senzingSchema.getLogger failed; returned (%v).",
+	69:   "Exit  " + Prefix + "Migrate() returned (%v).",
+	70:   "Enter " + Prefix + "VerifySchema().",
+	71:   "Exit  " + Prefix + "VerifySchema(); json.Marshal failed; returned (%v).",
+	72:   "Exit  " + Prefix + "VerifySchema(); settingsparser.New failed; returned (%v).",
+	73:   "Exit  " + Prefix + "VerifySchema(); parser.GetResourcePath failed; returned (%v).",
+	74:   "Exit  " + Prefix + "VerifySchema(); parser.GetDatabaseUrls failed; returned (%v).",
+	75:   "Exit  " + Prefix + "VerifySchema(); senzingSchema.verifyDatabase failed; returned (%v).",
+	76:   "Exit  " + Prefix + "VerifySchema(); senzingSchema.getLogger failed; returned (%v).",
+	79:   "Exit  " + Prefix + "VerifySchema() returned (%d, %v).",
+	80:   "Enter " + Prefix + "GrantRuntimeAccess(%s).",
+	81:   "Exit  " + Prefix + "GrantRuntimeAccess(%s); json.Marshal failed; returned (%v).",
+	82:   "Exit  " + Prefix + "GrantRuntimeAccess(%s); settingsparser.New failed; returned (%v).",
+	83:   "Exit  " + Prefix + "GrantRuntimeAccess(%s); parser.GetResourcePath failed; returned (%v).",
+	84:   "Exit  " + Prefix + "GrantRuntimeAccess(%s); senzingSchema.getDatabaseURLs failed; returned (%v).",
+	85:   "Exit  " + Prefix + "GrantRuntimeAccess(%s); senzingSchema.grantDatabase failed; returned (%v).",
+	86:   "Exit  " + Prefix + "GrantRuntimeAccess(%s); no role name; returned (%v).",
+	87:   "Exit  " + Prefix + "GrantRuntimeAccess(%s); senzingSchema.getLogger failed; returned (%v).",
+	89:   "Exit  " + Prefix + "GrantRuntimeAccess(%s) returned (%v).",
 	100:  "Enter " + Prefix + "processDatabase(%s, %s).",
 	101:  "Exit  " + Prefix + "processDatabase(%s, %s); url.Parse failed; returned (%v).",
 	102:  "Exit  " + Prefix + "processDatabase(%s, %s); connector.NewConnector failed; returned (%v).",
-	103:  "Exit  " + Prefix + "processDatabase(%s, %s); sqlExecutor.SetLogLevel failed; returned (%v).",
-	104:  "Exit  " + Prefix + "processDatabase(%s, %s); sqlExecutor.RegisterObserver failed; returned (%v).",
-	105:  "Exit  " + Prefix + "processDatabase(%s, %s); sqlExecutor.ProcessFileName failed; returned (%v).",
+	106:  "Exit  " + Prefix + "processDatabase(%s, %s); Senzing schema already exists; returned (%v).",
+	107:  "Exit  " + Prefix + "processDatabase(%s, %s); getInstalledSchemaVersion failed; returned (%v).",
+	108:  "Exit  " + Prefix + "processDatabase(%s, %s); incompatible schema version; returned (%v).",
 	109:  "Exit  " + Prefix + "processDatabase(%s, %s) returned (%v).",
+	110:  "Exit  " + Prefix + "processDatabase(%s, %s); getSQLFile failed; returned (%v).",
+	111:  "Exit  " + Prefix + "processDatabase(%s, %s); dry run; returned (%v).",
+	112:  "Exit  " + Prefix + "processDatabase(%s, %s); senzingSchema.ensureDatabaseSchema failed; returned (%v).",
+	113:  "Exit  " + Prefix + "processDatabase(%s, %s); senzingSchema.readSQLFile failed; returned (%v).",
+	114:  "Exit  " + Prefix + "processDatabase(%s, %s); getCatalog failed; returned (%v).",
+	115:  "Exit  " + Prefix + "processDatabase(%s, %s); database.ExecContext failed; returned (%v).",
+	200:  "Enter " + Prefix + "migrateDatabase(%s, %s).",
+	201:  "Exit  " + Prefix + "migrateDatabase(%s, %s); url.Parse failed; returned (%v).",
+	202:  "Exit  " + Prefix + "migrateDatabase(%s, %s); findMigrations failed; returned (%v).",
+	203:  "Exit  " + Prefix + "migrateDatabase(%s, %s); connector.NewConnector failed; returned (%v).",
+	204:  "Exit  " + Prefix + "migrateDatabase(%s, %s); getInstalledSchemaVersion failed; returned (%v).",
+	205:  "Exit  " + Prefix + "migrateDatabase(%s, %s); no installed schema version; returned (%v).",
+	206:  "Exit  " + Prefix + "migrateDatabase(%s, %s); migration tracking table failed; returned (%v).",
+	207:  "Exit  " + Prefix + "migrateDatabase(%s, %s); applying migration failed; returned (%v).",
+	208:  "Exit  " + Prefix + "migrateDatabase(%s, %s); recordMigration failed; returned (%v).",
+	209:  "Exit  " + Prefix + "migrateDatabase(%s, %s) returned (%v).",
+	210:  "Exit  " + Prefix + "migrateDatabase(%s, %s); senzingSchema.parseSQLFile failed; returned (%v).",
+	211:  "Exit  " + Prefix + "migrateDatabase(%s, %s); senzingSchema.getSQLFile failed; returned (%v).",
+	212:  "Exit  " + Prefix + "migrateDatabase(%s, %s); senzingSchema.readSQLFile failed; returned (%v).",
+	300:  "Enter " + Prefix + "verifyDatabase(%s, %s).",
+	301:  "Exit  " + Prefix + "verifyDatabase(%s, %s); url.Parse failed; returned (%v).",
+	302:  "Exit  " + Prefix + "verifyDatabase(%s, %s); getSQLFile failed; returned (%v).",
+	303:  "Exit  " + Prefix + "verifyDatabase(%s, %s); senzingSchema.readSQLFile failed; returned (%v).",
+	304:  "Exit  " + Prefix + "verifyDatabase(%s, %s); connector.NewConnector failed; returned (%v).",
+	305:  "Exit  " + Prefix + "verifyDatabase(%s, %s); getSchemaDefinition failed; returned (%v).",
+	309:  "Exit  " + Prefix + "verifyDatabase(%s, %s) returned (%v).",
+	400:  "Enter " + Prefix + "grantDatabase(%s, %s).",
+	401:  "Exit  " + Prefix + "grantDatabase(%s, %s); url.Parse failed; returned (%v).",
+	402:  "Exit  " + Prefix + "grantDatabase(%s, %s); SQLite has no roles; returned (%v).",
+	403:  "Exit  " + Prefix + "grantDatabase(%s, %s); reading SQL file failed; returned (%v).",
+	404:  "Exit  " + Prefix + "grantDatabase(%s, %s); connector.NewConnector failed; returned (%v).",
+	405:  "Exit  " + Prefix + "grantDatabase(%s, %s); role query failed; returned (%v).",
+	406:  "Exit  " + Prefix + "grantDatabase(%s, %s); creating role failed; returned (%v).",
+	407:  "Exit  " + Prefix + "grantDatabase(%s, %s); GRANT failed; returned (%v).",
+	408:  "Exit  " + Prefix + "grantDatabase(%s, %s); dry run; returned (%v).",
+	409:  "Exit  " + Prefix + "grantDatabase(%s, %s) returned (%v).",
 	1001: Prefix + "InitializeSenzing parameters: %+v",
 	1002: Prefix + "RegisterObserver parameters: %+v",
 	1003: Prefix + "SetLogLevel parameters: %+v",
 	1004: Prefix + "SetObserverOrigin parameters: %+v",
 	1005: Prefix + "UnregisterObserver parameters: %+v",
+	1006: Prefix + "Migrate parameters: %+v",
+	1007: Prefix + "VerifySchema parameters: %+v",
+	1008: Prefix + "GrantRuntimeAccess parameters: %+v",
 	1011: Prefix + "InitializeSenzing(); json.Marshal failed; returned (%v).",
 	1012: Prefix + "InitializeSenzing(); settingsparser.New failed; returned (%v).",
 	1013: Prefix + "InitializeSenzing(); parser.GetResourcePath failed; returned (%v).",
 	1014: Prefix + "InitializeSenzing(); parser.GetDatabaseUrls failed; returned (%v).",
 	1015: Prefix + "InitializeSenzing(); senzingSchema.processDatabase failed; returned (%v).",
+	1016: Prefix + "InitializeSenzing(); senzingSchema.getLogger failed; returned (%v).",
 	1021: Prefix + "RegisterObserver(%s); json.Marshal failed; returned (%v).",
 	1022: Prefix + "RegisterObserver(%s); senzingSchema.observers.RegisterObserver failed; returned (%v).",
 	1031: Prefix + "SetLogLevel(%s); json.Marshal failed; returned (%v).",
@@ -81,12 +181,101 @@ var IDMessages = map[int]string{
 	1033: Prefix + "senzingSchema.getLogger().SetLogLevel(%s) failed; returned (%v).",
 	1041: Prefix + "UnregisterObserver(%s); json.Marshal failed; returned (%v).",
 	1042: Prefix + "UnregisterObserver(%s); senzingSchema.observers.UnregisterObserver failed; returned (%v).",
+	1051: Prefix + "SetObserverOrigin(%s); json.Marshal failed; returned (%v).",
+	1061: Prefix + "Migrate(); json.Marshal failed; returned (%v).",
+	1062: Prefix + "Migrate(); settingsparser.New failed; returned (%v).",
+	1063: Prefix + "Migrate(); parser.GetResourcePath failed; returned (%v).",
+	1064: Prefix + "Migrate(); parser.GetDatabaseUrls failed; returned (%v).",
+	1065: Prefix + "Migrate(); senzingSchema.migrateDatabase failed; returned (%v).",
+	1066: Prefix + "Migrate(); senzingSchema.getLogger failed; returned (%v).",
+	1071: Prefix + "VerifySchema(); json.Marshal failed; returned (%v).",
+	1072: Prefix + "VerifySchema(); settingsparser.New failed; returned (%v).",
+	1073: Prefix + "VerifySchema(); parser.GetResourcePath failed; returned (%v).",
+	1074: Prefix + "VerifySchema(); parser.GetDatabaseUrls failed; returned (%v).",
+	1075: Prefix + "VerifySchema(); senzingSchema.verifyDatabase failed; returned (%v).",
+	1076: Prefix + "VerifySchema(); senzingSchema.getLogger failed; returned (%v).",
+	1081: Prefix + "GrantRuntimeAccess(%s); json.Marshal failed; returned (%v).",
+	1082: Prefix + "GrantRuntimeAccess(%s); settingsparser.New failed; returned (%v).",
+	1083: Prefix + "GrantRuntimeAccess(%s); parser.GetResourcePath failed; returned (%v).",
+	1084: Prefix + "GrantRuntimeAccess(%s); senzingSchema.getDatabaseURLs failed; returned (%v).",
+	1085: Prefix + "GrantRuntimeAccess(%s); senzingSchema.grantDatabase failed; returned (%v).",
+	1086: Prefix + "GrantRuntimeAccess(%s); no role name; returned (%v).",
+	1087: Prefix + "GrantRuntimeAccess(%s); senzingSchema.getLogger failed; returned (%v).",
+	1101: Prefix + "processDatabase(%s, %s); url.Parse failed; returned (%v).",
+	1102: Prefix + "processDatabase(%s, %s); connector.NewConnector failed; returned (%v).",
+	1107: Prefix + "processDatabase(%s, %s); getInstalledSchemaVersion failed; returned (%v).",
+	1108: Prefix + "processDatabase(%s, %s); incompatible schema version; returned (%v).",
+	1110: Prefix + "processDatabase(%s, %s); getSQLFile failed; returned (%v).",
+	1112: Prefix + "processDatabase(%s, %s); senzingSchema.ensureDatabaseSchema failed; returned (%v).",
+	1113: Prefix + "processDatabase(%s, %s); senzingSchema.readSQLFile failed; returned (%v).",
+	1114: Prefix + "processDatabase(%s, %s); getCatalog failed; returned (%v).",
+	1115: Prefix + "processDatabase(%s, %s); database.ExecContext failed; returned (%v).",
+	1201: Prefix + "migrateDatabase(%s, %s); url.Parse failed; returned (%v).",
+	1202: Prefix + "migrateDatabase(%s, %s); findMigrations failed; returned (%v).",
+	1203: Prefix + "migrateDatabase(%s, %s); connector.NewConnector failed; returned (%v).",
+	1204: Prefix + "migrateDatabase(%s, %s); getInstalledSchemaVersion failed; returned (%v).",
+	1205: Prefix + "migrateDatabase(%s, %s); no installed schema version; returned (%v).",
+	1206: Prefix + "migrateDatabase(%s, %s); migration tracking table failed; returned (%v).",
+	1207: Prefix + "migrateDatabase(%s, %s); applying migration failed; returned (%v).",
+	1208: Prefix + "migrateDatabase(%s, %s); recordMigration failed; returned (%v).",
+	1210: Prefix + "migrateDatabase(%s, %s); senzingSchema.parseSQLFile failed; returned (%v).",
+	1211: Prefix + "migrateDatabase(%s, %s); senzingSchema.getSQLFile failed; returned (%v).",
+	1212: Prefix + "migrateDatabase(%s, %s); senzingSchema.readSQLFile failed; returned (%v).",
+	1301: Prefix + "verifyDatabase(%s, %s); url.Parse failed; returned (%v).",
+	1302: Prefix + "verifyDatabase(%s, %s); getSQLFile failed; returned (%v).",
+	1303: Prefix + "verifyDatabase(%s, %s); senzingSchema.readSQLFile failed; returned (%v).",
+	1304: Prefix + "verifyDatabase(%s, %s); connector.NewConnector failed; returned (%v).",
+	1305: Prefix + "verifyDatabase(%s, %s); getSchemaDefinition failed; returned (%v).",
+	1401: Prefix + "grantDatabase(%s, %s); url.Parse failed; returned (%v).",
+	1403: Prefix + "grantDatabase(%s, %s); reading SQL file failed; returned (%v).",
+	1404: Prefix + "grantDatabase(%s, %s); connector.NewConnector failed; returned (%v).",
+	1405: Prefix + "grantDatabase(%s, %s); role query failed; returned (%v).",
+	1406: Prefix + "grantDatabase(%s, %s); creating role failed; returned (%v).",
+	1407: Prefix + "grantDatabase(%s, %s); GRANT failed; returned (%v).",
 	2001: "Sent SQL in %s to database %s",
+	2002: "Senzing schema already exists in database %s.  No SQL from %s sent.",
+	2003: "Created table %s in database %s",
+	2004: "Created index %s on table %s in database %s",
+	2005: "Table %s already exists in database %s.  Skipped.",
+	2006: "Index %s on table %s already exists in database %s.  Skipped.",
+	2007: "Database %s has Senzing schema version %s",
+	2008: "Created schema %s in database %s",
+	2101: "Applied migration %s (schema version %s to %s) to database %s",
+	2102: "Database %s is at schema version %s.  No migrations applied.",
+	2201: "Schema in database %s matches %s",
+	2202: "Created role %s in database %s",
+	2203: "Granted role %s access to %d tables in database %s",
+	3001: "Senzing schema partially exists in database %s.  Sending %d of %d statements to complete it.",
+	3002: "Database %s has Senzing schema version %s, which is older than version %s in %s",
+	3003: "SQL file %s does not exist.  Using the copy embedded in init-database.",
+	3004: "Rolled back all SQL sent to database %s",
+	3005: "Undid \"%s\" in database %s",
+	3006: "Database %s has no roles.  No access granted to %s.",
+	3007: "Database schema %s applies only to PostgreSQL.  Ignored for database %s.",
+	3101: "Schema drift in database %s: %s %s is %s; expected: %q; actual: %q",
+	4001: "Could not create table %s in database %s; error: %v",
+	4002: "Could not create index %s on table %s in database %s; error: %v",
+	4003: "Could not execute \"%s\" in database %s; error: %v",
+	4004: "Database %s has Senzing schema version %s, which is newer than version %s in %s.  Database not changed.",
+	4005: "Database %s has Senzing schema version %s, which is incompatible with version %s in %s.  Database not changed.",
+	4006: "Database %s has no Senzing schema version in SYS_VARS.  Create the schema before migrating.",
+	4007: "Migration %s is already recorded for database %s, but the database is at schema version %s",
+	4008: "Migration %s failed executing \"%s\" in database %s; error: %v",
+	4009: "Database %s is at schema version %s.  No migrations lead to version %s in %s",
+	4010: "Could not roll back SQL sent to database %s; error: %v",
+	4011: "Could not undo with \"%s\" in database %s; error: %v",
+	4012: "Could not create role %s in database %s; error: %v",
+	4013: "Database %s resolves schema %s, not %s.  Check search_path settings for the database and role.",
 	8001: Prefix + "InitializeSenzing",
 	8002: Prefix + "RegisterObserver",
 	8003: Prefix + "SetLogLevel",
 	8004: Prefix + "SetObserverOrigin",
 	8005: Prefix + "UnregisterObserver",
+	8006: Prefix + "processDatabase",
+	8007: Prefix + "Migrate",
+	8008: Prefix + "migrateDatabase",
+	8009: Prefix + "VerifySchema",
+	8010: Prefix + "GrantRuntimeAccess",
 }
 
 // Status strings for specific messages.
@@ -0,0 +1,139 @@
+package senzingschema
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A file of SQL that takes a database from one schema version to the next.
+type schemaMigration struct {
+	File        string // Path to the file of SQL.
+	FromVersion string // Schema version the file expects.
+	ToVersion   string // Schema version after the file has been applied.
+}
+
+// Either a database or a transaction on it.
+type sqlExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Name of the table recording which migrations have been applied to a database.
+const migrationTable = "INIT_DATABASE_MIGRATIONS"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Per database scheme, the SQL that creates the migration tracking table.  The table name is formatted in.
+var migrationTableDDL = map[string]string{
+	"mssql":      "CREATE TABLE %s (MIGRATION_FILE VARCHAR(250) NOT NULL, FROM_VERSION VARCHAR(25) NOT NULL, TO_VERSION VARCHAR(25) NOT NULL, APPLIED_DT DATETIME NOT NULL, PRIMARY KEY(MIGRATION_FILE))",
+	"mysql":      "CREATE TABLE %s (MIGRATION_FILE VARCHAR(250) NOT NULL, FROM_VERSION VARCHAR(25) NOT NULL, TO_VERSION VARCHAR(25) NOT NULL, APPLIED_DT DATETIME NOT NULL, PRIMARY KEY(MIGRATION_FILE))",
+	"oci":        "CREATE TABLE %s (MIGRATION_FILE VARCHAR2(250) NOT NULL, FROM_VERSION VARCHAR2(25) NOT NULL, TO_VERSION VARCHAR2(25) NOT NULL, APPLIED_DT DATE NOT NULL, PRIMARY KEY(MIGRATION_FILE))",
+	"postgresql": "CREATE TABLE %s (MIGRATION_FILE VARCHAR(250) NOT NULL, FROM_VERSION VARCHAR(25) NOT NULL, TO_VERSION VARCHAR(25) NOT NULL, APPLIED_DT TIMESTAMP NOT NULL, PRIMARY KEY(MIGRATION_FILE))",
+	"sqlite3":    "CREATE TABLE %s (MIGRATION_FILE VARCHAR(250) NOT NULL, FROM_VERSION VARCHAR(25) NOT NULL, TO_VERSION VARCHAR(25) NOT NULL, APPLIED_DT TIMESTAMP NOT NULL, PRIMARY KEY(MIGRATION_FILE))",
+}
+
+// Migration files are named "<from>-<to>.sql" or "<from>-<to>_<description>.sql".  Example: "4.0-4.1_add-index.sql".
+var regexpMigrationFile = regexp.MustCompile(`^(\d+(?:\.\d+){0,2})-(\d+(?:\.\d+){0,2})(?:_[^/\\]*)?\.sql$`)
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Create the migration tracking table in a schema if it does not exist.
+func ensureMigrationTable(ctx context.Context, database *sql.DB, scheme string, catalog *databaseCatalog, schema string) error {
+	table := qualifiedName(schema, migrationTable)
+	if catalog.tables[table] {
+		return nil
+	}
+	ddl, ok := migrationTableDDL[scheme]
+	if !ok {
+		return fmt.Errorf("cannot create %s for database scheme: %s", table, scheme)
+	}
+	_, err := database.ExecContext(ctx, fmt.Sprintf(ddl, table))
+	return err
+}
+
+// List the migrations for a database scheme, ordered by the version they start from.
+// A missing directory means there are no migrations.
+func findMigrations(directory string, scheme string) ([]schemaMigration, error) {
+	result := []schemaMigration{}
+	schemeDirectory := filepath.Join(directory, scheme)
+	entries, err := os.ReadDir(schemeDirectory)
+	if errors.Is(err, os.ErrNotExist) {
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := regexpMigrationFile.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		if compareSchemaVersions(match[2], match[1]) != schemaVersionNewer {
+			return result, fmt.Errorf("migration %s does not move to a newer schema version", entry.Name())
+		}
+		result = append(result, schemaMigration{
+			File:        filepath.Join(schemeDirectory, entry.Name()),
+			FromVersion: match[1],
+			ToVersion:   match[2],
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return compareSchemaVersions(result[i].FromVersion, result[j].FromVersion) == schemaVersionOlder
+	})
+	for index := 1; index < len(result); index++ {
+		if compareSchemaVersions(result[index-1].FromVersion, result[index].FromVersion) == schemaVersionSame {
+			return result, fmt.Errorf("migrations %s and %s both start at schema version %s", result[index-1].File, result[index].File, result[index].FromVersion)
+		}
+	}
+	return result, err
+}
+
+// The migrations already recorded in the tracking table of a schema.
+func getAppliedMigrations(ctx context.Context, database *sql.DB, schema string) (map[string]bool, error) {
+	return queryNames(ctx, database, "SELECT MIGRATION_FILE FROM "+qualifiedName(schema, migrationTable))
+}
+
+// The migration that starts at the given schema version, if any.
+func nextMigration(migrations []schemaMigration, version string) (schemaMigration, bool) {
+	for _, migration := range migrations {
+		if compareSchemaVersions(migration.FromVersion, version) == schemaVersionSame {
+			return migration, true
+		}
+	}
+	return schemaMigration{}, false
+}
+
+// Record an applied migration and advance the schema version in SYS_VARS, both in the given schema.
+// Values come from validated file names and versions, so literals are safe and portable across drivers.
+func recordMigration(ctx context.Context, executor sqlExecutor, migration schemaMigration, schema string) error {
+	_, err := executor.ExecContext(ctx, fmt.Sprintf(
+		"INSERT INTO %s (MIGRATION_FILE, FROM_VERSION, TO_VERSION, APPLIED_DT) VALUES ('%s', '%s', '%s', CURRENT_TIMESTAMP)",
+		qualifiedName(schema, migrationTable), filepath.Base(migration.File), migration.FromVersion, migration.ToVersion))
+	if err != nil {
+		return err
+	}
+	_, err = executor.ExecContext(ctx, fmt.Sprintf(
+		"UPDATE %s SET VAR_VALUE = '%s' WHERE VAR_GROUP = 'VERSION' AND VAR_CODE = 'SCHEMA'",
+		qualifiedName(schema, versionTable), migration.ToVersion))
+	return err
+}
@@ -0,0 +1,136 @@
+package senzingschema
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/senzing-garage/go-databasing/dbhelper"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// A PostgreSQL schema name usable without quotes.
+// Unquoted, the name folds to lower case identically in search_path and in CREATE SCHEMA.
+var regexpDatabaseSchema = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*$`)
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+/*
+The PostgreSQL schema Senzing will use for a database URL from the Senzing settings.
+The schema is set by the URL's "schema" query parameter, which sets search_path for each session.
+When DatabaseSchema is set, the settings must name the same schema, or Senzing would not find its tables at runtime.
+*/
+func (senzingSchema *BasicSenzingSchema) getDatabaseSchema(settingsURL string) (string, error) {
+	parsedURL, err := dbhelper.ParseDatabaseURL(settingsURL)
+	if err != nil {
+		return "", err
+	}
+	if parsedURL.Scheme != "postgresql" {
+		if len(senzingSchema.DatabaseSchema) > 0 {
+			senzingSchema.log(3007, senzingSchema.DatabaseSchema, parsedURL.Redacted())
+		}
+		return "", err
+	}
+	settingsSchema := getDatabaseSchemaName(parsedURL)
+	result := settingsSchema
+	if len(senzingSchema.DatabaseSchema) > 0 {
+		if len(settingsSchema) == 0 {
+			return "", fmt.Errorf("database URL %s in the Senzing settings has no schema parameter, so Senzing would not use schema %s; add ?schema=%s", parsedURL.Redacted(), senzingSchema.DatabaseSchema, senzingSchema.DatabaseSchema)
+		}
+		if !strings.EqualFold(settingsSchema, senzingSchema.DatabaseSchema) {
+			return "", fmt.Errorf("database URL %s in the Senzing settings uses schema %s, not %s", parsedURL.Redacted(), settingsSchema, senzingSchema.DatabaseSchema)
+		}
+		result = senzingSchema.DatabaseSchema
+	}
+	if len(result) > 0 && !regexpDatabaseSchema.MatchString(result) {
+		return "", fmt.Errorf("invalid PostgreSQL schema name: %s", result)
+	}
+	return result, err
+}
+
+/*
+Create the PostgreSQL schema named by a database URL, if missing, then confirm the session resolves to it.
+In a dry run, the statement that would create the schema is returned instead of executed.
+*/
+func (senzingSchema *BasicSenzingSchema) ensureDatabaseSchema(ctx context.Context, database *sql.DB, parsedURL *url.URL) ([]sqlStatement, error) {
+	var err error
+	var result []sqlStatement
+	schemaName := getDatabaseSchemaName(parsedURL)
+	if parsedURL.Scheme != "postgresql" || len(schemaName) == 0 {
+		return result, err
+	}
+	storedName := strings.ToLower(schemaName)
+
+	// Create the schema.
+
+	var schemaCount int
+	err = database.QueryRowContext(ctx, "SELECT COUNT(*) FROM pg_namespace WHERE nspname = $1", storedName).Scan(&schemaCount)
+	if err != nil {
+		return result, err
+	}
+	if schemaCount == 0 {
+		statement := classifyStatement("CREATE SCHEMA IF NOT EXISTS " + schemaName)
+		if senzingSchema.DryRun {
+			return append(result, statement), err
+		}
+		_, err = database.ExecContext(ctx, statement.SQL)
+		if err != nil {
+			senzingSchema.log(4003, statement.SQL, parsedURL.Redacted(), err)
+			return result, err
+		}
+		senzingSchema.log(2008, schemaName, parsedURL.Redacted())
+	}
+
+	// Confirm search_path resolves to the schema.
+
+	var currentSchema sql.NullString
+	err = database.QueryRowContext(ctx, "SELECT current_schema()").Scan(&currentSchema)
+	if err != nil {
+		return result, err
+	}
+	if currentSchema.String != storedName {
+		senzingSchema.log(4013, parsedURL.Redacted(), currentSchema.String, storedName)
+		return result, fmt.Errorf("database %s resolves schema %q, not %q", parsedURL.Redacted(), currentSchema.String, storedName)
+	}
+	return result, err
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The schema named by a database URL's "schema" query parameter.
+func getDatabaseSchemaName(parsedURL *url.URL) string {
+	return parsedURL.Query().Get("schema")
+}
+
+// A database URL that uses a schema.  A URL already naming a different schema is an error.
+func withDatabaseSchema(databaseURL string, schemaName string) (string, error) {
+	parsedURL, err := dbhelper.ParseDatabaseURL(databaseURL)
+	if err != nil {
+		return databaseURL, err
+	}
+	if parsedURL.Scheme != "postgresql" {
+		return databaseURL, err
+	}
+	urlSchema := getDatabaseSchemaName(parsedURL)
+	if len(urlSchema) > 0 {
+		if !strings.EqualFold(urlSchema, schemaName) {
+			return databaseURL, fmt.Errorf("database URL %s uses schema %s, but the Senzing settings use schema %s", parsedURL.Redacted(), urlSchema, schemaName)
+		}
+		return databaseURL, err
+	}
+	separator := "?"
+	if strings.Contains(databaseURL, "?") {
+		separator = "&"
+	}
+	return databaseURL + separator + "schema=" + url.QueryEscape(schemaName), err
+}
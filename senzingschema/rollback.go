@@ -0,0 +1,53 @@
+package senzingschema
+
+import (
+	"fmt"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Database schemes whose CREATE, ALTER and DROP statements can be rolled back within a transaction.
+// MySQL and Oracle implicitly commit each DDL statement.
+var transactionalDDL = map[string]bool{
+	"mssql":      true,
+	"postgresql": true,
+	"sqlite3":    true,
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The statements that undo executed statements on a database without transactional DDL.
+// Tables created are dropped, which also removes their indexes and rows.
+// Indexes created on tables that already existed are dropped individually.
+// Statements are returned in the reverse order of creation.
+func compensatingStatements(scheme string, executed []sqlStatement) []string {
+	result := []string{}
+	createdTables := map[string]bool{}
+	for _, statement := range executed {
+		if statement.Kind == statementKindCreateTable {
+			createdTables[statement.Object] = true
+		}
+	}
+	for index := len(executed) - 1; index >= 0; index-- {
+		statement := executed[index]
+		switch statement.Kind {
+		case statementKindCreateTable:
+			result = append(result, "DROP TABLE "+statement.Object)
+		case statementKindCreateIndex:
+			if createdTables[statement.Table] {
+				continue
+			}
+			switch scheme {
+			case "mssql", "mysql":
+				result = append(result, fmt.Sprintf("DROP INDEX %s ON %s", statement.Object, statement.Table))
+			default:
+				result = append(result, "DROP INDEX "+statement.Object)
+			}
+		}
+	}
+	return result
+}
@@ -1,19 +1,31 @@
 package senzingschema
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
+	"database/sql"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/senzing-garage/go-databasing/connector"
 	"github.com/senzing-garage/go-databasing/dbhelper"
-	"github.com/senzing-garage/go-databasing/sqlexecutor"
 	"github.com/senzing-garage/go-helpers/settingsparser"
 	"github.com/senzing-garage/go-logging/logging"
 	"github.com/senzing-garage/go-observing/notifier"
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/go-observing/subject"
+	"github.com/senzing-garage/init-database/initerror"
+	"github.com/senzing-garage/init-database/redact"
+	"github.com/senzing-garage/init-database/resources"
+	"github.com/senzing-garage/init-database/sqlpool"
 )
 
 // ----------------------------------------------------------------------------
@@ -22,10 +34,19 @@ import (
 
 // BasicSenzingSchema is the default implementation of the SenzingSchema interface.
 type BasicSenzingSchema struct {
-	SenzingSettings string `json:"senzingSettings,omitempty"`
-	SQLFile         string `json:"sqlFile,omitempty"`
+	AdminDatabaseURL    string            `json:"adminDatabaseUrl,omitempty"`
+	DatabaseSchema      string            `json:"databaseSchema,omitempty"`
+	DryRun              bool              `json:"dryRun,omitempty"`
+	DryRunOutput        io.Writer         `json:"-"`
+	MigrationDirectory  string            `json:"migrationDirectory,omitempty"`
+	SenzingSettings     string            `json:"senzingSettings,omitempty"`
+	SQLFile             string            `json:"sqlFile,omitempty"`
+	SQLFileMap          map[string]string `json:"sqlFileMap,omitempty"`
+	SQLVariables        map[string]string `json:"sqlVariables,omitempty"`
+	TargetSchemaVersion string            `json:"targetSchemaVersion,omitempty"`
 
 	logger         logging.Logging
+	loggerErr      error
 	logLevelName   string
 	observerOrigin string
 	observers      subject.Subject
@@ -50,60 +71,908 @@ var traceOptions = []interface{}{
 // --- Logging ----------------------------------------------------------------
 
 // Get the Logger singleton.
+// If the logger cannot be created, loggerErr records why and a logger with default options is used.
 func (senzingSchema *BasicSenzingSchema) getLogger() logging.Logging {
-	var err error
 	if senzingSchema.logger == nil {
 		options := []interface{}{
 			&logging.OptionCallerSkip{Value: 4},
 		}
-		senzingSchema.logger, err = logging.NewSenzingLogger(ComponentID, IDMessages, options...)
+		senzingSchema.logger, senzingSchema.loggerErr = logging.NewSenzingLogger(ComponentID, IDMessages, options...)
+		if senzingSchema.loggerErr != nil {
+			senzingSchema.logger, _ = logging.New()
+		}
+	}
+	return senzingSchema.logger
+}
+
+// Log message.
+func (senzingSchema *BasicSenzingSchema) log(messageNumber int, details ...interface{}) {
+	senzingSchema.getLogger().Log(messageNumber, details...)
+}
+
+// Debug.
+func (senzingSchema *BasicSenzingSchema) debug(messageNumber int, details ...interface{}) {
+	details = append(details, debugOptions...)
+	senzingSchema.getLogger().Log(messageNumber, details...)
+}
+
+// Trace method entry.
+func (senzingSchema *BasicSenzingSchema) traceEntry(messageNumber int, details ...interface{}) {
+	details = append(details, traceOptions...)
+	senzingSchema.getLogger().Log(messageNumber, details...)
+}
+
+// Trace method exit.
+func (senzingSchema *BasicSenzingSchema) traceExit(messageNumber int, details ...interface{}) {
+	details = append(details, traceOptions...)
+	senzingSchema.getLogger().Log(messageNumber, details...)
+}
+
+// --- Errors -----------------------------------------------------------------
+
+// Classify an error, identifying it by the debug message describing it.
+func wrapError(class error, messageNumber int, err error) error {
+	return initerror.New(class, ComponentID, messageNumber, err)
+}
+
+// The class of an error reading a SQL file.
+func sqlFileClass(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return initerror.ErrFileMissing
+	}
+	return initerror.ErrSQLFile
+}
+
+// --- Misc -------------------------------------------------------------------
+
+// Given a database URL, detemine the correct SQL file and send the statements the database still needs.
+func (senzingSchema *BasicSenzingSchema) processDatabase(ctx context.Context, resourcePath string, databaseURL string) error {
+	var err error
+
+	// Prolog.
+
+	debugMessageNumber := 0
+	traceExitMessageNumber := 109
+	if senzingSchema.getLogger().IsDebug() {
+
+		// If DEBUG, log error exit.
+
+		defer func() {
+			if debugMessageNumber > 0 {
+				senzingSchema.debug(debugMessageNumber, resourcePath, redact.DatabaseURL(databaseURL), err)
+			}
+		}()
+
+		// If TRACE, Log on entry/exit.
+
+		if senzingSchema.getLogger().IsTrace() {
+			entryTime := time.Now()
+			senzingSchema.traceEntry(100, resourcePath, redact.DatabaseURL(databaseURL))
+			defer func() {
+				senzingSchema.traceExit(traceExitMessageNumber, resourcePath, redact.DatabaseURL(databaseURL), err, time.Since(entryTime))
+			}()
+		}
+	}
+
+	// Determine which SQL file to process.
+
+	parsedURL, err := dbhelper.ParseDatabaseURL(databaseURL)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 101, 1101
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
+	}
+
+	sqlFile, err := senzingSchema.getSQLFile(resourcePath, databaseURL, parsedURL.Scheme, parsedURL.Redacted())
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 110, 1110
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
+	}
+
+	// Read the SQL statements to be sent.
+
+	statements, err := senzingSchema.readSQLFile(resourcePath, sqlFile, parsedURL.Scheme)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 113, 1113
+		return wrapError(sqlFileClass(err), debugMessageNumber, err)
+	}
+
+	// Inspect the live catalog to determine what already exists.
+	// A dry run does not connect to a SQLite database that does not exist yet, because connecting would create it.
+
+	var database *sql.DB
+	var schemaStatements []sqlStatement
+	catalog := &databaseCatalog{
+		indexes: map[string]bool{},
+		tables:  map[string]bool{},
+	}
+	if !senzingSchema.DryRun || !isMissingSqliteDatabase(parsedURL) {
+		database, err = sqlpool.Open(ctx, databaseURL)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 102, 1102
+			return wrapError(initerror.ErrConnect, debugMessageNumber, err)
+		}
+		defer sqlpool.Close(database, databaseURL)
+		schemaStatements, err = senzingSchema.ensureDatabaseSchema(ctx, database, parsedURL)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 112, 1112
+			return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
+		}
+		catalog, err = getCatalog(ctx, database, parsedURL.Scheme, statements)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 114, 1114
+			return wrapError(initerror.ErrConnect, debugMessageNumber, err)
+		}
+	}
+
+	// Refuse to touch a database whose schema is newer than, or incompatible with, the SQL file.
+
+	installedVersion, err := getInstalledSchemaVersion(ctx, database, catalog, schemaVersionTableSchema(statements))
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 107, 1107
+		return wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+	declaredVersion := declaredSchemaVersion(statements)
+	switch compareSchemaVersions(installedVersion, declaredVersion) {
+	case schemaVersionNewer:
+		senzingSchema.log(4004, parsedURL.Redacted(), installedVersion, declaredVersion, sqlFile)
+		err = fmt.Errorf("database schema version %s is newer than version %s in %s", installedVersion, declaredVersion, sqlFile)
+		traceExitMessageNumber, debugMessageNumber = 108, 1108
+		return wrapError(initerror.ErrSchemaVersion, debugMessageNumber, err)
+	case schemaVersionIncompatible:
+		senzingSchema.log(4005, parsedURL.Redacted(), installedVersion, declaredVersion, sqlFile)
+		err = fmt.Errorf("database schema version %s is incompatible with version %s in %s", installedVersion, declaredVersion, sqlFile)
+		traceExitMessageNumber, debugMessageNumber = 108, 1108
+		return wrapError(initerror.ErrSchemaVersion, debugMessageNumber, err)
+	case schemaVersionOlder:
+		senzingSchema.log(3002, parsedURL.Redacted(), installedVersion, declaredVersion, sqlFile)
+	default:
+		if len(installedVersion) > 0 {
+			senzingSchema.log(2007, parsedURL.Redacted(), installedVersion)
+		}
+	}
+
+	// Decide which statements are needed.
+
+	plan := planSchema(statements, catalog)
+
+	// In a dry run, describe the plan instead of executing it.
+
+	if senzingSchema.DryRun {
+		plan.Execute = append(schemaStatements, plan.Execute...)
+		err = senzingSchema.printPlan(parsedURL.Redacted(), sqlFile, installedVersion, plan)
+		traceExitMessageNumber, debugMessageNumber = 111, 0 // debugMessageNumber=0 because it's not an error.
+		return err
+	}
+
+	switch plan.State {
+	case schemaStateComplete:
+		senzingSchema.log(2002, parsedURL.Redacted(), sqlFile)
+		senzingSchema.notifyProcessDatabase(ctx, parsedURL.Redacted(), sqlFile, "complete", nil, plan.Skip, err)
+		traceExitMessageNumber, debugMessageNumber = 106, 0 // debugMessageNumber=0 because it's not an error.
+		return err
+	case schemaStatePartial:
+		senzingSchema.log(3001, parsedURL.Redacted(), len(plan.Execute), len(statements))
+	}
+
+	// Send the needed statements.  On failure, nothing sent by this run remains.
+
+	if transactionalDDL[parsedURL.Scheme] {
+		err = senzingSchema.executeInTransaction(ctx, database, parsedURL.Redacted(), plan.Execute)
+	} else {
+		err = senzingSchema.executeWithCompensation(ctx, database, parsedURL.Scheme, parsedURL.Redacted(), plan.Execute)
+	}
+	if err != nil {
+		senzingSchema.notifyProcessDatabase(ctx, parsedURL.Redacted(), sqlFile, "failed", nil, plan.Skip, err)
+		traceExitMessageNumber, debugMessageNumber = 115, 1115
+		return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
+	}
+	executed := plan.Execute
+	for _, statement := range executed {
+		switch statement.Kind {
+		case statementKindCreateTable:
+			senzingSchema.log(2003, statement.Object, parsedURL.Redacted())
+		case statementKindCreateIndex:
+			senzingSchema.log(2004, statement.Object, statement.Table, parsedURL.Redacted())
+		}
+	}
+	for _, statement := range plan.Skip {
+		switch statement.Kind {
+		case statementKindCreateTable:
+			senzingSchema.log(2005, statement.Object, parsedURL.Redacted())
+		case statementKindCreateIndex:
+			senzingSchema.log(2006, statement.Object, statement.Table, parsedURL.Redacted())
+		}
+	}
+
+	state := "created"
+	if plan.State == schemaStatePartial {
+		state = "completed"
+	}
+	senzingSchema.notifyProcessDatabase(ctx, parsedURL.Redacted(), sqlFile, state, executed, plan.Skip, err)
+	senzingSchema.log(2001, sqlFile, parsedURL.Redacted())
+	return err
+}
+
+// Given a database URL, compare the schema in the database with the schema its SQL file declares.
+func (senzingSchema *BasicSenzingSchema) verifyDatabase(ctx context.Context, resourcePath string, databaseURL string) ([]SchemaDifference, error) {
+	var err error
+	var result []SchemaDifference
+
+	// Prolog.
+
+	debugMessageNumber := 0
+	traceExitMessageNumber := 309
+	if senzingSchema.getLogger().IsDebug() {
+
+		// If DEBUG, log error exit.
+
+		defer func() {
+			if debugMessageNumber > 0 {
+				senzingSchema.debug(debugMessageNumber, resourcePath, redact.DatabaseURL(databaseURL), err)
+			}
+		}()
+
+		// If TRACE, Log on entry/exit.
+
+		if senzingSchema.getLogger().IsTrace() {
+			entryTime := time.Now()
+			senzingSchema.traceEntry(300, resourcePath, redact.DatabaseURL(databaseURL))
+			defer func() {
+				senzingSchema.traceExit(traceExitMessageNumber, resourcePath, redact.DatabaseURL(databaseURL), err, time.Since(entryTime))
+			}()
+		}
+	}
+
+	parsedURL, err := dbhelper.ParseDatabaseURL(databaseURL)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 301, 1301
+		return result, wrapError(initerror.ErrSettings, debugMessageNumber, err)
+	}
+
+	// Determine the schema the SQL file declares.
+
+	sqlFile, err := senzingSchema.getSQLFile(resourcePath, databaseURL, parsedURL.Scheme, parsedURL.Redacted())
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 302, 1302
+		return result, wrapError(initerror.ErrSettings, debugMessageNumber, err)
+	}
+	statements, err := senzingSchema.readSQLFile(resourcePath, sqlFile, parsedURL.Scheme)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 303, 1303
+		return result, wrapError(sqlFileClass(err), debugMessageNumber, err)
+	}
+
+	// Determine the schema the database has.
+
+	database, err := sqlpool.Open(ctx, databaseURL)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 304, 1304
+		return result, wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+	defer sqlpool.Close(database, databaseURL)
+	actual, err := getSchemaDefinition(ctx, database, parsedURL.Scheme)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 305, 1305
+		return result, wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+
+	// Compare.
+
+	result = compareSchemas(parsedURL.Redacted(), declaredSchema(statements), actual)
+	if len(result) == 0 {
+		senzingSchema.log(2201, parsedURL.Redacted(), sqlFile)
+	}
+	for _, difference := range result {
+		senzingSchema.log(3101, parsedURL.Redacted(), difference.Kind, difference.Object, difference.Problem, difference.Expected, difference.Actual)
+	}
+	return result, err
+}
+
+// Given a database URL, create the runtime role if missing and grant it DML access to every table in the SQL file.
+func (senzingSchema *BasicSenzingSchema) grantDatabase(ctx context.Context, resourcePath string, databaseURL string, roleName string, password string) error {
+	var err error
+
+	// Prolog.
+
+	debugMessageNumber := 0
+	traceExitMessageNumber := 409
+	if senzingSchema.getLogger().IsDebug() {
+
+		// If DEBUG, log error exit.
+
+		defer func() {
+			if debugMessageNumber > 0 {
+				senzingSchema.debug(debugMessageNumber, resourcePath, roleName, err)
+			}
+		}()
+
+		// If TRACE, Log on entry/exit.
+
+		if senzingSchema.getLogger().IsTrace() {
+			entryTime := time.Now()
+			senzingSchema.traceEntry(400, resourcePath, roleName)
+			defer func() {
+				senzingSchema.traceExit(traceExitMessageNumber, resourcePath, roleName, err, time.Since(entryTime))
+			}()
+		}
+	}
+
+	parsedURL, err := dbhelper.ParseDatabaseURL(databaseURL)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 401, 1401
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
+	}
+	if parsedURL.Scheme == "sqlite3" {
+		senzingSchema.log(3006, parsedURL.Redacted(), roleName)
+		traceExitMessageNumber, debugMessageNumber = 402, 0 // debugMessageNumber=0 because it's not an error.
+		return err
+	}
+
+	// Determine the tables to grant access to.
+
+	sqlFile, err := senzingSchema.getSQLFile(resourcePath, databaseURL, parsedURL.Scheme, parsedURL.Redacted())
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 403, 1403
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
+	}
+	statements, err := senzingSchema.readSQLFile(resourcePath, sqlFile, parsedURL.Scheme)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 403, 1403
+		return wrapError(sqlFileClass(err), debugMessageNumber, err)
+	}
+	grants := grantStatements(parsedURL.Scheme, roleName, tableNames(statements))
+	if schemaName := getDatabaseSchemaName(parsedURL); parsedURL.Scheme == "postgresql" && len(schemaName) > 0 {
+		grants = append([]string{grantSchemaStatement(schemaName, roleName)}, grants...)
+	}
+
+	// Connect to the database.
+
+	databaseConnector, err := connector.NewConnector(ctx, databaseURL)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 404, 1404
+		return wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+	database := sql.OpenDB(databaseConnector)
+	defer database.Close()
+
+	// Create the role, if missing.  Statements are not logged because they contain the password.
+
+	var roleCount int
+	err = database.QueryRowContext(ctx, roleExistsSQL[parsedURL.Scheme], storedRoleName(parsedURL.Scheme, roleName)).Scan(&roleCount)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 405, 1405
+		return wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+	if roleCount == 0 {
+		var createStatements []string
+		createStatements, err = createRoleStatements(parsedURL.Scheme, roleName, password)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 406, 1406
+			return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
+		}
+		if senzingSchema.DryRun {
+			_, err = fmt.Fprintf(senzingSchema.getDryRunOutput(), "Role would be created: %s\n", roleName)
+			if err != nil {
+				traceExitMessageNumber, debugMessageNumber = 406, 1406
+				return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
+			}
+		} else {
+			for _, statement := range createStatements {
+				_, err = database.ExecContext(ctx, statement)
+				if err != nil {
+					senzingSchema.log(4012, roleName, parsedURL.Redacted(), err)
+					traceExitMessageNumber, debugMessageNumber = 406, 1406
+					return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, fmt.Errorf("could not create role %s in database %s: %w", roleName, parsedURL.Redacted(), err))
+				}
+			}
+			senzingSchema.log(2202, roleName, parsedURL.Redacted())
+		}
+	}
+
+	// Grant access to each table.
+
+	if senzingSchema.DryRun {
+		_, err = fmt.Fprintf(senzingSchema.getDryRunOutput(), "Grants that would be executed: %d\n", len(grants))
+		for _, statement := range grants {
+			if err != nil {
+				break
+			}
+			_, err = fmt.Fprintf(senzingSchema.getDryRunOutput(), "    %s\n", statement)
+		}
+		traceExitMessageNumber, debugMessageNumber = 408, 0 // debugMessageNumber=0 because it's not an error.
+		return err
+	}
+	for _, statement := range grants {
+		_, err = database.ExecContext(ctx, statement)
+		if err != nil {
+			senzingSchema.log(4003, statement, parsedURL.Redacted(), err)
+			traceExitMessageNumber, debugMessageNumber = 407, 1407
+			return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, fmt.Errorf("%s: %w", statement, err))
+		}
+	}
+	senzingSchema.log(2203, roleName, len(grants), parsedURL.Redacted())
+	return err
+}
+
+// The database URLs to work on.  An AdminDatabaseURL replaces the single database URL in the Senzing settings.
+func (senzingSchema *BasicSenzingSchema) getDatabaseURLs(ctx context.Context, parser settingsparser.SettingsParser) ([]string, error) {
+	databaseURLs, err := parser.GetDatabaseURLs(ctx)
+	if err != nil {
+		return databaseURLs, err
+	}
+	if len(senzingSchema.AdminDatabaseURL) > 0 && len(databaseURLs) != 1 {
+		return databaseURLs, fmt.Errorf("an admin database URL requires exactly one database in the Senzing settings; found %d", len(databaseURLs))
+	}
+	result := []string{}
+	for _, databaseURL := range databaseURLs {
+		var schemaName string
+		schemaName, err = senzingSchema.getDatabaseSchema(databaseURL)
+		if err != nil {
+			return result, err
+		}
+		if len(senzingSchema.AdminDatabaseURL) > 0 {
+			databaseURL = senzingSchema.AdminDatabaseURL
+		}
+		if len(schemaName) > 0 {
+			databaseURL, err = withDatabaseSchema(databaseURL, schemaName)
+			if err != nil {
+				return result, err
+			}
+		}
+		result = append(result, databaseURL)
+	}
+	return result, err
+}
+
+// Send statements in a single transaction.  If any statement fails, the transaction is rolled back.
+func (senzingSchema *BasicSenzingSchema) executeInTransaction(ctx context.Context, database *sql.DB, redactedURL string, statements []sqlStatement) error {
+	transaction, err := database.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		_, err = transaction.ExecContext(ctx, statement.SQL)
+		if err != nil {
+			senzingSchema.logStatementFailure(statement, redactedURL, err)
+			rollbackErr := transaction.Rollback()
+			if rollbackErr != nil {
+				senzingSchema.log(4010, redactedURL, rollbackErr)
+				return fmt.Errorf("%s: %w; rollback failed: %w", statement.SQL, err, rollbackErr)
+			}
+			senzingSchema.log(3004, redactedURL)
+			return fmt.Errorf("%s: %w", statement.SQL, err)
+		}
+	}
+	return transaction.Commit()
+}
+
+// Roll back a transaction after a failed statement.  A nil transaction has nothing to roll back.
+func (senzingSchema *BasicSenzingSchema) rollback(transaction *sql.Tx, redactedURL string) {
+	if transaction == nil {
+		return
+	}
+	err := transaction.Rollback()
+	if err != nil {
+		senzingSchema.log(4010, redactedURL, err)
+		return
+	}
+	senzingSchema.log(3004, redactedURL)
+}
+
+// Send statements one at a time.  If a statement fails, drop the objects created before the failure.
+func (senzingSchema *BasicSenzingSchema) executeWithCompensation(ctx context.Context, database *sql.DB, scheme string, redactedURL string, statements []sqlStatement) error {
+	for index, statement := range statements {
+		_, err := database.ExecContext(ctx, statement.SQL)
+		if err != nil {
+			senzingSchema.logStatementFailure(statement, redactedURL, err)
+			for _, compensatingSQL := range compensatingStatements(scheme, statements[:index]) {
+				_, dropErr := database.ExecContext(ctx, compensatingSQL)
+				if dropErr != nil {
+					senzingSchema.log(4011, compensatingSQL, redactedURL, dropErr)
+					continue
+				}
+				senzingSchema.log(3005, compensatingSQL, redactedURL)
+			}
+			return fmt.Errorf("%s: %w", statement.SQL, err)
+		}
+	}
+	return nil
+}
+
+// Determine the SQL file for a database.
+// In order of precedence: SQLFileMap entry for the database URL (as given or redacted), SQLFile, default file for the database scheme.
+func (senzingSchema *BasicSenzingSchema) getSQLFile(resourcePath string, databaseURL string, scheme string, redactedURL string) (string, error) {
+	if sqlFile, ok := senzingSchema.SQLFileMap[databaseURL]; ok {
+		return sqlFile, nil
+	}
+	if sqlFile, ok := senzingSchema.SQLFileMap[redactedURL]; ok {
+		return sqlFile, nil
+	}
+	if len(senzingSchema.SQLFile) > 0 {
+		return senzingSchema.SQLFile, nil
+	}
+	return defaultSQLFile(resourcePath, scheme)
+}
+
+// Where dry run output is written.
+func (senzingSchema *BasicSenzingSchema) getDryRunOutput() io.Writer {
+	if senzingSchema.DryRunOutput == nil {
+		return os.Stdout
+	}
+	return senzingSchema.DryRunOutput
+}
+
+// True when a TargetSchemaVersion is given and the version matches it.
+func (senzingSchema *BasicSenzingSchema) isTargetSchemaVersion(version string) bool {
+	return compareSchemaVersions(version, senzingSchema.TargetSchemaVersion) == schemaVersionSame
+}
+
+// Given a database URL, apply migrations in order until the database reaches the target schema version.
+func (senzingSchema *BasicSenzingSchema) migrateDatabase(ctx context.Context, resourcePath string, databaseURL string) error {
+	var err error
+
+	// Prolog.
+
+	debugMessageNumber := 0
+	traceExitMessageNumber := 209
+	if senzingSchema.getLogger().IsDebug() {
+
+		// If DEBUG, log error exit.
+
+		defer func() {
+			if debugMessageNumber > 0 {
+				senzingSchema.debug(debugMessageNumber, resourcePath, redact.DatabaseURL(databaseURL), err)
+			}
+		}()
+
+		// If TRACE, Log on entry/exit.
+
+		if senzingSchema.getLogger().IsTrace() {
+			entryTime := time.Now()
+			senzingSchema.traceEntry(200, resourcePath, redact.DatabaseURL(databaseURL))
+			defer func() {
+				senzingSchema.traceExit(traceExitMessageNumber, resourcePath, redact.DatabaseURL(databaseURL), err, time.Since(entryTime))
+			}()
+		}
+	}
+
+	parsedURL, err := dbhelper.ParseDatabaseURL(databaseURL)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 201, 1201
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
+	}
+
+	// Find the migration files for this type of database.
+
+	migrationDirectory := senzingSchema.MigrationDirectory
+	if len(migrationDirectory) == 0 {
+		migrationDirectory = resourcePath + "/schema/migrations"
+	}
+	migrations, err := findMigrations(migrationDirectory, parsedURL.Scheme)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 202, 1202
+		return wrapError(sqlFileClass(err), debugMessageNumber, err)
+	}
+
+	// The SQL file for the database names the schema holding SYS_VARS; the tracking table is kept beside it.
+
+	sqlFile, err := senzingSchema.getSQLFile(resourcePath, databaseURL, parsedURL.Scheme, parsedURL.Redacted())
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 211, 1211
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
+	}
+	sqlFileStatements, err := senzingSchema.readSQLFile(resourcePath, sqlFile, parsedURL.Scheme)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 212, 1212
+		return wrapError(sqlFileClass(err), debugMessageNumber, err)
+	}
+	schema := schemaVersionTableSchema(sqlFileStatements)
+
+	// Connect to the database.
+
+	database, err := sqlpool.Open(ctx, databaseURL)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 203, 1203
+		return wrapError(initerror.ErrConnect, debugMessageNumber, err)
+	}
+	defer sqlpool.Close(database, databaseURL)
+
+	// Determine the installed schema version.
+
+	catalog, err := getCatalog(ctx, database, parsedURL.Scheme, sqlFileStatements)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 204, 1204
+		return wrapError(initerror.ErrSchemaQuery, debugMessageNumber, err)
+	}
+	version, err := getInstalledSchemaVersion(ctx, database, catalog, schema)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 204, 1204
+		return wrapError(initerror.ErrSchemaQuery, debugMessageNumber, err)
+	}
+	if len(version) == 0 {
+		senzingSchema.log(4006, parsedURL.Redacted())
+		err = fmt.Errorf("no Senzing schema version found in database %s", parsedURL.Redacted())
+		traceExitMessageNumber, debugMessageNumber = 205, 1205
+		return wrapError(initerror.ErrSchemaVersion, debugMessageNumber, err)
+	}
+
+	// Prepare the tracking table.
+
+	err = ensureMigrationTable(ctx, database, parsedURL.Scheme, catalog, schema)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 206, 1206
+		return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
+	}
+	appliedMigrations, err := getAppliedMigrations(ctx, database, schema)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 206, 1206
+		return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
+	}
+
+	// Read the migrations to apply, so an unreadable file or unresolved SQL variable is reported before any is applied.
+
+	pendingMigrations := []schemaMigration{}
+	pendingStatements := [][]sqlStatement{}
+	for pendingVersion := version; !senzingSchema.isTargetSchemaVersion(pendingVersion); {
+		migration, ok := nextMigration(migrations, pendingVersion)
+		if !ok {
+			break
+		}
+		if len(senzingSchema.TargetSchemaVersion) > 0 && compareSchemaVersions(migration.ToVersion, senzingSchema.TargetSchemaVersion) == schemaVersionNewer {
+			break
+		}
+		var statements []sqlStatement
+		statements, err = senzingSchema.parseSQLFile(migration.File, parsedURL.Scheme)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 210, 1210
+			return wrapError(sqlFileClass(err), debugMessageNumber, err)
+		}
+		pendingMigrations = append(pendingMigrations, migration)
+		pendingStatements = append(pendingStatements, statements)
+		pendingVersion = migration.ToVersion
+	}
+
+	// Apply migrations, one version at a time.
+
+	appliedCount := 0
+	for index, migration := range pendingMigrations {
+		if appliedMigrations[normalizeObjectName(filepath.Base(migration.File))] {
+			senzingSchema.log(4007, migration.File, parsedURL.Redacted(), version)
+			err = fmt.Errorf("migration %s is recorded in %s, but database %s is at schema version %s", migration.File, migrationTable, parsedURL.Redacted(), version)
+			traceExitMessageNumber, debugMessageNumber = 207, 1207
+			return wrapError(initerror.ErrSchemaVersion, debugMessageNumber, err)
+		}
+
+		// Where DDL is transactional, a migration and its record are committed together.
+
+		var executor sqlExecutor = database
+		var transaction *sql.Tx
+		if transactionalDDL[parsedURL.Scheme] {
+			transaction, err = database.BeginTx(ctx, nil)
+			if err != nil {
+				traceExitMessageNumber, debugMessageNumber = 207, 1207
+				return wrapError(initerror.ErrConnect, debugMessageNumber, err)
+			}
+			executor = transaction
+		}
+		for _, statement := range pendingStatements[index] {
+			_, err = executor.ExecContext(ctx, statement.SQL)
+			if err != nil {
+				senzingSchema.log(4008, migration.File, statement.SQL, parsedURL.Redacted(), err)
+				senzingSchema.rollback(transaction, parsedURL.Redacted())
+				traceExitMessageNumber, debugMessageNumber = 207, 1207
+				return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, fmt.Errorf("%s: %s: %w", migration.File, statement.SQL, err))
+			}
+		}
+		err = recordMigration(ctx, executor, migration, schema)
 		if err != nil {
-			panic(err)
+			senzingSchema.rollback(transaction, parsedURL.Redacted())
+			traceExitMessageNumber, debugMessageNumber = 208, 1208
+			return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
 		}
+		if transaction != nil {
+			err = transaction.Commit()
+			if err != nil {
+				traceExitMessageNumber, debugMessageNumber = 208, 1208
+				return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
+			}
+		}
+		senzingSchema.log(2101, migration.File, migration.FromVersion, migration.ToVersion, parsedURL.Redacted())
+		if senzingSchema.observers != nil {
+			details := map[string]string{
+				"databaseURL":   parsedURL.Redacted(),
+				"migrationFile": migration.File,
+				"fromVersion":   migration.FromVersion,
+				"toVersion":     migration.ToVersion,
+			}
+			go func() {
+				notifier.Notify(ctx, senzingSchema.observers, senzingSchema.observerOrigin, ComponentID, 8008, err, details)
+			}()
+		}
+		version = migration.ToVersion
+		appliedCount++
 	}
-	return senzingSchema.logger
+
+	// Verify the requested version was reached.
+
+	if len(senzingSchema.TargetSchemaVersion) > 0 && !senzingSchema.isTargetSchemaVersion(version) {
+		senzingSchema.log(4009, parsedURL.Redacted(), version, senzingSchema.TargetSchemaVersion, migrationDirectory)
+		err = fmt.Errorf("no migration path from schema version %s to %s for database %s", version, senzingSchema.TargetSchemaVersion, parsedURL.Redacted())
+		traceExitMessageNumber, debugMessageNumber = 207, 1207
+		return wrapError(initerror.ErrSchemaVersion, debugMessageNumber, err)
+	}
+	if appliedCount == 0 {
+		senzingSchema.log(2102, parsedURL.Redacted(), version)
+	}
+	return err
 }
 
-// Log message.
-func (senzingSchema *BasicSenzingSchema) log(messageNumber int, details ...interface{}) {
-	senzingSchema.getLogger().Log(messageNumber, details...)
+// Log a failed statement, naming the table or index it was meant to create.
+func (senzingSchema *BasicSenzingSchema) logStatementFailure(statement sqlStatement, redactedURL string, err error) {
+	switch statement.Kind {
+	case statementKindCreateTable:
+		senzingSchema.log(4001, statement.Object, redactedURL, err)
+	case statementKindCreateIndex:
+		senzingSchema.log(4002, statement.Object, statement.Table, redactedURL, err)
+	default:
+		senzingSchema.log(4003, statement.SQL, redactedURL, err)
+	}
 }
 
-// Debug.
-func (senzingSchema *BasicSenzingSchema) debug(messageNumber int, details ...interface{}) {
-	details = append(details, debugOptions...)
-	senzingSchema.getLogger().Log(messageNumber, details...)
+// Read SQL statements from a file.
+// If the file belongs in the Senzing resource path but is missing, the copy embedded in init-database is used.
+func (senzingSchema *BasicSenzingSchema) readSQLFile(resourcePath string, sqlFile string, scheme string) ([]sqlStatement, error) {
+	name, err := filepath.Rel(resourcePath, sqlFile)
+	if err == nil && resources.IsMissing(resourcePath, filepath.ToSlash(name)) {
+		content, err := resources.ReadFile(filepath.ToSlash(name))
+		if err != nil {
+			return nil, err
+		}
+		senzingSchema.log(3003, sqlFile)
+		return senzingSchema.parseSQLText(sqlFile, string(content), scheme)
+	}
+	return senzingSchema.parseSQLFile(sqlFile, scheme)
 }
 
-// Trace method entry.
-func (senzingSchema *BasicSenzingSchema) traceEntry(messageNumber int, details ...interface{}) {
-	details = append(details, traceOptions...)
-	senzingSchema.getLogger().Log(messageNumber, details...)
+// Read SQL statements from a file on disk.
+func (senzingSchema *BasicSenzingSchema) parseSQLFile(sqlFile string, scheme string) ([]sqlStatement, error) {
+	content, err := os.ReadFile(filepath.Clean(sqlFile))
+	if err != nil {
+		return nil, err
+	}
+	return senzingSchema.parseSQLText(sqlFile, string(content), scheme)
 }
 
-// Trace method exit.
-func (senzingSchema *BasicSenzingSchema) traceExit(messageNumber int, details ...interface{}) {
-	details = append(details, traceOptions...)
-	senzingSchema.getLogger().Log(messageNumber, details...)
+// Render the SQLVariables into SQL text, then split it into statements.
+func (senzingSchema *BasicSenzingSchema) parseSQLText(sqlFile string, sqlText string, scheme string) ([]sqlStatement, error) {
+	sqlText, err := renderSQL(sqlFile, sqlText, senzingSchema.SQLVariables)
+	if err != nil {
+		return nil, err
+	}
+	return parseSQL(strings.NewReader(sqlText), scheme)
 }
 
-// --- Misc -------------------------------------------------------------------
+// Describe, for a dry run, the statements that would be sent to a database.
+func (senzingSchema *BasicSenzingSchema) printPlan(redactedURL string, sqlFile string, installedVersion string, plan schemaPlan) error {
+	var state string
+	switch plan.State {
+	case schemaStateComplete:
+		state = "complete; no statements would be sent"
+	case schemaStatePartial:
+		state = "partial; missing objects would be created"
+	default:
+		state = "empty; schema would be created"
+	}
+	if len(installedVersion) == 0 {
+		installedVersion = "none"
+	}
+	buffer := &bytes.Buffer{}
+	fmt.Fprintf(buffer, "Database: %s\n", redactedURL)
+	fmt.Fprintf(buffer, "  SQL file: %s\n", sqlFile)
+	fmt.Fprintf(buffer, "  Installed schema version: %s\n", installedVersion)
+	fmt.Fprintf(buffer, "  Schema: %s\n", state)
+	fmt.Fprintf(buffer, "  Statements that would be executed: %d (skipped: %d)\n", len(plan.Execute), len(plan.Skip))
+	for _, statement := range plan.Execute {
+		fmt.Fprintf(buffer, "    %s\n", statement.SQL)
+	}
+	_, err := senzingSchema.getDryRunOutput().Write(buffer.Bytes())
+	return err
+}
 
-// Given a database URL, detemine the correct SQL file and send the statements to the database.
-func (senzingSchema *BasicSenzingSchema) processDatabase(ctx context.Context, resourcePath string, databaseURL string) error {
+// Tell observers what processDatabase did to a database.
+func (senzingSchema *BasicSenzingSchema) notifyProcessDatabase(ctx context.Context, redactedURL string, sqlFile string, state string, executed []sqlStatement, skipped []sqlStatement, err error) {
+	if senzingSchema.observers == nil {
+		return
+	}
+	details := map[string]string{
+		"databaseURL":    redactedURL,
+		"sqlFile":        sqlFile,
+		"state":          state,
+		"createdTables":  strings.Join(objectNames(executed, statementKindCreateTable), ","),
+		"createdIndexes": strings.Join(objectNames(executed, statementKindCreateIndex), ","),
+		"skippedTables":  strings.Join(objectNames(skipped, statementKindCreateTable), ","),
+		"skippedIndexes": strings.Join(objectNames(skipped, statementKindCreateIndex), ","),
+		"statementsSent": strconv.Itoa(len(executed)),
+	}
+	go func() {
+		notifier.Notify(ctx, senzingSchema.observers, senzingSchema.observerOrigin, ComponentID, 8006, err, details)
+	}()
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// True for a SQLite database whose file does not exist yet, including in-memory databases.
+func isMissingSqliteDatabase(parsedURL *url.URL) bool {
+	if parsedURL.Scheme != "sqlite3" {
+		return false
+	}
+	if parsedURL.Query().Get("mode") == "memory" {
+		return true
+	}
+	_, err := os.Stat(filepath.Clean(parsedURL.Path))
+	return err != nil
+}
+
+// The SQL file shipped in the Senzing resources for a database scheme.
+func defaultSQLFile(resourcePath string, scheme string) (string, error) {
+	switch scheme {
+	case "mssql":
+		return resourcePath + "/schema/szcore-schema-mssql-create.sql", nil
+	case "mysql":
+		return resourcePath + "/schema/szcore-schema-mysql-create.sql", nil
+	case "oci":
+		return resourcePath + "/schema/szcore-schema-oracle-create.sql", nil
+	case "postgresql":
+		return resourcePath + "/schema/szcore-schema-postgresql-create.sql", nil
+	case "sqlite3":
+		return resourcePath + "/schema/szcore-schema-sqlite-create.sql", nil
+	default:
+		return "", fmt.Errorf("%w: %s", initerror.ErrUnknownDatabaseScheme, scheme)
+	}
+}
+
+// Names of the objects created by statements of a given kind.
+func objectNames(statements []sqlStatement, kind int) []string {
+	result := []string{}
+	for _, statement := range statements {
+		if statement.Kind == kind {
+			result = append(result, statement.Object)
+		}
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The GrantRuntimeAccess method creates a login role, if it does not exist, and grants it
+the row-level privileges Senzing needs on every table in each database's SQL file.
+It is used with AdminDatabaseURL so that the account Senzing runs with does not need DDL rights.
+SQLite databases have no roles and are skipped.
+
+Input
+  - ctx: A context to control lifecycle.
+  - roleName: The role (user) Senzing connects as.
+  - password: The role's password, used only if the role is created.
+*/
+func (senzingSchema *BasicSenzingSchema) GrantRuntimeAccess(ctx context.Context, roleName string, password string) error {
 	var err error
 
 	// Prolog.
 
 	debugMessageNumber := 0
-	traceExitMessageNumber := 109
+	traceExitMessageNumber := 89
 	if senzingSchema.getLogger().IsDebug() {
 
 		// If DEBUG, log error exit.
 
 		defer func() {
 			if debugMessageNumber > 0 {
-				senzingSchema.debug(debugMessageNumber, resourcePath, databaseURL, err)
+				senzingSchema.debug(debugMessageNumber, roleName, err)
 			}
 		}()
 
@@ -111,87 +980,75 @@ func (senzingSchema *BasicSenzingSchema) processDatabase(ctx context.Context, re
 
 		if senzingSchema.getLogger().IsTrace() {
 			entryTime := time.Now()
-			senzingSchema.traceEntry(100, resourcePath, databaseURL)
-			defer func() {
-				senzingSchema.traceExit(traceExitMessageNumber, resourcePath, databaseURL, err, time.Since(entryTime))
-			}()
+			senzingSchema.traceEntry(80, roleName)
+			defer func() { senzingSchema.traceExit(traceExitMessageNumber, roleName, err, time.Since(entryTime)) }()
+		}
+
+		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
+
+		asJSON, err := redact.Marshal(senzingSchema)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 81, 1081
+			return err
 		}
+		senzingSchema.log(1008, string(asJSON))
 	}
 
-	// Determine which SQL file to process.
+	// A logger that could not be created is an error.
 
-	parsedURL, err := dbhelper.ParseDatabaseURL(databaseURL)
-	if err != nil {
-		traceExitMessageNumber, debugMessageNumber = 101, 1101
-		return err
+	if senzingSchema.loggerErr != nil {
+		traceExitMessageNumber, debugMessageNumber = 87, 1087
+		return wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, senzingSchema.loggerErr)
 	}
 
-	if len(senzingSchema.SQLFile) == 0 {
-		switch parsedURL.Scheme {
-		case "mssql":
-			senzingSchema.SQLFile = resourcePath + "/schema/szcore-schema-mssql-create.sql"
-		case "mysql":
-			senzingSchema.SQLFile = resourcePath + "/schema/szcore-schema-mysql-create.sql"
-		case "oci":
-			senzingSchema.SQLFile = resourcePath + "/schema/szcore-schema-oracle-create.sql"
-		case "postgresql":
-			senzingSchema.SQLFile = resourcePath + "/schema/szcore-schema-postgresql-create.sql"
-		case "sqlite3":
-			senzingSchema.SQLFile = resourcePath + "/schema/szcore-schema-sqlite-create.sql"
-		default:
-			return fmt.Errorf("unknown database scheme: %s", parsedURL.Scheme)
-		}
+	if len(roleName) == 0 {
+		err = fmt.Errorf("no runtime role name")
+		traceExitMessageNumber, debugMessageNumber = 86, 1086
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
 	}
 
-	// Connect to the database.
+	// Pull values out of SenzingEngineConfigurationJson.
 
-	databaseConnector, err := connector.NewConnector(ctx, databaseURL)
+	parser, err := settingsparser.New(senzingSchema.SenzingSettings)
 	if err != nil {
-		traceExitMessageNumber, debugMessageNumber = 102, 1102
-		return err
+		traceExitMessageNumber, debugMessageNumber = 82, 1082
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
 	}
-
-	// Create sqlExecutor to process file of SQL.
-
-	sqlExecutor := &sqlexecutor.BasicSQLExecutor{
-		DatabaseConnector: databaseConnector,
+	resourcePath, err := parser.GetResourcePath(ctx)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 83, 1083
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
 	}
-	err = sqlExecutor.SetLogLevel(ctx, senzingSchema.logLevelName)
+	databaseURLs, err := senzingSchema.getDatabaseURLs(ctx, parser)
 	if err != nil {
-		traceExitMessageNumber, debugMessageNumber = 103, 1103
-		return err
+		traceExitMessageNumber, debugMessageNumber = 84, 1084
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
 	}
 
-	// Add observers to sqlExecutor.
+	// Grant access in each database.
 
-	if senzingSchema.observers != nil {
-		for _, observer := range senzingSchema.observers.GetObservers(ctx) {
-			err = sqlExecutor.RegisterObserver(ctx, observer)
-			if err != nil {
-				traceExitMessageNumber, debugMessageNumber = 104, 1104
-				return err
-			}
+	for _, databaseURL := range databaseURLs {
+		err = senzingSchema.grantDatabase(ctx, resourcePath, databaseURL, roleName, password)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 85, 1085
+			return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
 		}
 	}
 
-	// TODO: add following when it becomes available.
-	// sqlExecutor.SetObserverOrigin(ctx, senzingSchema.observerOrigin)
-
-	// Process file of SQL
+	// Notify observers.
 
-	err = sqlExecutor.ProcessFileName(ctx, senzingSchema.SQLFile)
-	if err != nil {
-		traceExitMessageNumber, debugMessageNumber = 105, 1105
-		return err
+	if senzingSchema.observers != nil {
+		go func() {
+			details := map[string]string{
+				"role": roleName,
+			}
+			notifier.Notify(ctx, senzingSchema.observers, senzingSchema.observerOrigin, ComponentID, 8010, err, details)
+		}()
 	}
-	senzingSchema.log(2001, senzingSchema.SQLFile, parsedURL.Redacted())
+
 	return err
 }
 
-// ----------------------------------------------------------------------------
-// Interface methods
-// ----------------------------------------------------------------------------
-
 /*
 The InitializeSenzing method adds the Senzing database schema to the specified database.
 
@@ -225,12 +1082,19 @@ func (senzingSchema *BasicSenzingSchema) InitializeSenzing(ctx context.Context)
 
 		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
 
-		asJSON, err := json.Marshal(senzingSchema)
+		asJSON, err := redact.Marshal(senzingSchema)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 11, 1011
 			return err
 		}
-		senzingSchema.log(1001, senzingSchema, string(asJSON))
+		senzingSchema.log(1001, string(asJSON))
+	}
+
+	// A logger that could not be created is an error.
+
+	if senzingSchema.loggerErr != nil {
+		traceExitMessageNumber, debugMessageNumber = 16, 1016
+		return wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, senzingSchema.loggerErr)
 	}
 
 	// Pull values out of SenzingEngineConfigurationJson.
@@ -238,17 +1102,17 @@ func (senzingSchema *BasicSenzingSchema) InitializeSenzing(ctx context.Context)
 	parser, err := settingsparser.New(senzingSchema.SenzingSettings)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 12, 1012
-		return err
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
 	}
 	resourcePath, err := parser.GetResourcePath(ctx)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 13, 1013
-		return err
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
 	}
-	databaseURLs, err := parser.GetDatabaseURLs(ctx)
+	databaseURLs, err := senzingSchema.getDatabaseURLs(ctx, parser)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 14, 1014
-		return err
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
 	}
 
 	// Process each database.
@@ -257,7 +1121,7 @@ func (senzingSchema *BasicSenzingSchema) InitializeSenzing(ctx context.Context)
 		err = senzingSchema.processDatabase(ctx, resourcePath, databaseURL)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 15, 1015
-			return err
+			return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
 		}
 	}
 
@@ -273,6 +1137,96 @@ func (senzingSchema *BasicSenzingSchema) InitializeSenzing(ctx context.Context)
 	return err
 }
 
+/*
+The Migrate method applies schema migration files, in order, to bring each database
+from its installed Senzing schema version to the TargetSchemaVersion.
+If TargetSchemaVersion is empty, all available migrations are applied.
+
+Input
+  - ctx: A context to control lifecycle.
+*/
+func (senzingSchema *BasicSenzingSchema) Migrate(ctx context.Context) error {
+	var err error
+
+	// Prolog.
+
+	debugMessageNumber := 0
+	traceExitMessageNumber := 69
+	if senzingSchema.getLogger().IsDebug() {
+
+		// If DEBUG, log error exit.
+
+		defer func() {
+			if debugMessageNumber > 0 {
+				senzingSchema.debug(debugMessageNumber, err)
+			}
+		}()
+
+		// If TRACE, Log on entry/exit.
+
+		if senzingSchema.getLogger().IsTrace() {
+			entryTime := time.Now()
+			senzingSchema.traceEntry(60)
+			defer func() { senzingSchema.traceExit(traceExitMessageNumber, err, time.Since(entryTime)) }()
+		}
+
+		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
+
+		asJSON, err := redact.Marshal(senzingSchema)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 61, 1061
+			return err
+		}
+		senzingSchema.log(1006, string(asJSON))
+	}
+
+	// A logger that could not be created is an error.
+
+	if senzingSchema.loggerErr != nil {
+		traceExitMessageNumber, debugMessageNumber = 66, 1066
+		return wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, senzingSchema.loggerErr)
+	}
+
+	// Pull values out of SenzingEngineConfigurationJson.
+
+	parser, err := settingsparser.New(senzingSchema.SenzingSettings)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 62, 1062
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
+	}
+	resourcePath, err := parser.GetResourcePath(ctx)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 63, 1063
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
+	}
+	databaseURLs, err := senzingSchema.getDatabaseURLs(ctx, parser)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 64, 1064
+		return wrapError(initerror.ErrSettings, debugMessageNumber, err)
+	}
+
+	// Process each database.
+
+	for _, databaseURL := range databaseURLs {
+		err = senzingSchema.migrateDatabase(ctx, resourcePath, databaseURL)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 65, 1065
+			return wrapError(initerror.ErrSchemaDDL, debugMessageNumber, err)
+		}
+	}
+
+	// Notify observers.
+
+	if senzingSchema.observers != nil {
+		go func() {
+			details := map[string]string{}
+			notifier.Notify(ctx, senzingSchema.observers, senzingSchema.observerOrigin, ComponentID, 8007, err, details)
+		}()
+	}
+
+	return err
+}
+
 /*
 The RegisterObserver method adds the observer to the list of observers notified.
 
@@ -313,12 +1267,12 @@ func (senzingSchema *BasicSenzingSchema) RegisterObserver(ctx context.Context, o
 
 		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
 
-		asJSON, err := json.Marshal(senzingSchema)
+		asJSON, err := redact.Marshal(senzingSchema)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 21, 1021
 			return err
 		}
-		senzingSchema.log(1002, senzingSchema, string(asJSON))
+		senzingSchema.log(1002, string(asJSON))
 	}
 
 	// Create empty list of observers.
@@ -383,19 +1337,19 @@ func (senzingSchema *BasicSenzingSchema) SetLogLevel(ctx context.Context, logLev
 
 		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
 
-		asJSON, err := json.Marshal(senzingSchema)
+		asJSON, err := redact.Marshal(senzingSchema)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 31, 1031
 			return err
 		}
-		senzingSchema.log(1003, senzingSchema, string(asJSON))
+		senzingSchema.log(1003, string(asJSON))
 	}
 
 	// Verify value of logLevelName.
 
 	if !logging.IsValidLogLevelName(logLevelName) {
 		traceExitMessageNumber, debugMessageNumber = 32, 1032
-		return fmt.Errorf("invalid error level: %s", logLevelName)
+		return wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, fmt.Errorf("invalid error level: %s", logLevelName))
 	}
 
 	// Set senzingSchema log level.
@@ -404,7 +1358,7 @@ func (senzingSchema *BasicSenzingSchema) SetLogLevel(ctx context.Context, logLev
 	err = senzingSchema.getLogger().SetLogLevel(logLevelName)
 	if err != nil {
 		traceExitMessageNumber, debugMessageNumber = 33, 1033
-		return err
+		return wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, err)
 	}
 
 	// Notify observers.
@@ -457,14 +1411,12 @@ func (senzingSchema *BasicSenzingSchema) SetObserverOrigin(ctx context.Context,
 
 		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
 
-		asJSON, err := json.Marshal(senzingSchema)
+		asJSON, err := redact.Marshal(senzingSchema)
 		if err != nil {
-			debugMessageNumber = 1051
-			traceExitMessageNumber = 51
 			traceExitMessageNumber, debugMessageNumber = 51, 1051
 			return
 		}
-		senzingSchema.log(1004, senzingSchema, string(asJSON))
+		senzingSchema.log(1004, string(asJSON))
 	}
 
 	// Set origin in dependent services.
@@ -524,12 +1476,12 @@ func (senzingSchema *BasicSenzingSchema) UnregisterObserver(ctx context.Context,
 
 		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
 
-		asJSON, err := json.Marshal(senzingSchema)
+		asJSON, err := redact.Marshal(senzingSchema)
 		if err != nil {
 			traceExitMessageNumber, debugMessageNumber = 41, 1041
 			return err
 		}
-		senzingSchema.log(1005, senzingSchema, string(asJSON))
+		senzingSchema.log(1005, string(asJSON))
 	}
 
 	// Remove observer from this service.
@@ -560,3 +1512,101 @@ func (senzingSchema *BasicSenzingSchema) UnregisterObserver(ctx context.Context,
 
 	return err
 }
+
+/*
+The VerifySchema method compares the tables, columns, primary keys and indexes in each database
+with those declared by the database's SQL file.
+The database is not changed.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - The differences found in all databases.  An empty list means no drift.
+*/
+func (senzingSchema *BasicSenzingSchema) VerifySchema(ctx context.Context) ([]SchemaDifference, error) {
+	var err error
+	result := []SchemaDifference{}
+
+	// Prolog.
+
+	debugMessageNumber := 0
+	traceExitMessageNumber := 79
+	if senzingSchema.getLogger().IsDebug() {
+
+		// If DEBUG, log error exit.
+
+		defer func() {
+			if debugMessageNumber > 0 {
+				senzingSchema.debug(debugMessageNumber, err)
+			}
+		}()
+
+		// If TRACE, Log on entry/exit.
+
+		if senzingSchema.getLogger().IsTrace() {
+			entryTime := time.Now()
+			senzingSchema.traceEntry(70)
+			defer func() { senzingSchema.traceExit(traceExitMessageNumber, len(result), err, time.Since(entryTime)) }()
+		}
+
+		// If DEBUG, log input parameters. Must be done after establishing DEBUG and TRACE logging.
+
+		asJSON, err := redact.Marshal(senzingSchema)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 71, 1071
+			return result, err
+		}
+		senzingSchema.log(1007, string(asJSON))
+	}
+
+	// A logger that could not be created is an error.
+
+	if senzingSchema.loggerErr != nil {
+		traceExitMessageNumber, debugMessageNumber = 76, 1076
+		return result, wrapError(initerror.ErrInvalidLogLevel, debugMessageNumber, senzingSchema.loggerErr)
+	}
+
+	// Pull values out of SenzingEngineConfigurationJson.
+
+	parser, err := settingsparser.New(senzingSchema.SenzingSettings)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 72, 1072
+		return result, wrapError(initerror.ErrSettings, debugMessageNumber, err)
+	}
+	resourcePath, err := parser.GetResourcePath(ctx)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 73, 1073
+		return result, wrapError(initerror.ErrSettings, debugMessageNumber, err)
+	}
+	databaseURLs, err := senzingSchema.getDatabaseURLs(ctx, parser)
+	if err != nil {
+		traceExitMessageNumber, debugMessageNumber = 74, 1074
+		return result, wrapError(initerror.ErrSettings, debugMessageNumber, err)
+	}
+
+	// Verify each database.
+
+	for _, databaseURL := range databaseURLs {
+		var differences []SchemaDifference
+		differences, err = senzingSchema.verifyDatabase(ctx, resourcePath, databaseURL)
+		if err != nil {
+			traceExitMessageNumber, debugMessageNumber = 75, 1075
+			return result, wrapError(initerror.ErrConnect, debugMessageNumber, err)
+		}
+		result = append(result, differences...)
+	}
+
+	// Notify observers.
+
+	if senzingSchema.observers != nil {
+		go func() {
+			details := map[string]string{
+				"differences": strconv.Itoa(len(result)),
+			}
+			notifier.Notify(ctx, senzingSchema.observers, senzingSchema.observerOrigin, ComponentID, 8009, err, details)
+		}()
+	}
+
+	return result, err
+}
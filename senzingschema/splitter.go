@@ -0,0 +1,188 @@
+package senzingschema
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var (
+	// A line holding only the MS SQL batch separator, optionally with a repeat count, which is ignored.
+	regexpBatchSeparator = regexp.MustCompile(`(?im)^[ \t]*GO(?:[ \t]+\d+)?[ \t]*$`)
+
+	// A line holding only "/", which ends an Oracle PL/SQL block.
+	regexpBlockTerminator = regexp.MustCompile(`(?m)^[ \t]*/[ \t]*$`)
+
+	// The start of a PostgreSQL dollar-quoted string.  Example: $$ or $body$.
+	regexpDollarQuote = regexp.MustCompile(`^\$[A-Za-z_]*\$`)
+
+	// The start of an MS SQL batch that must be sent whole because it contains ";" itself or must be alone in its batch.
+	regexpProceduralBatch = regexp.MustCompile(`(?is)^(?:BEGIN|DECLARE|CREATE\s+(?:OR\s+ALTER\s+)?(?:FUNCTION|PROC|PROCEDURE|TRIGGER|TYPE|VIEW)\b)`)
+
+	// The start of an Oracle PL/SQL block, which runs to a line holding only "/".
+	regexpPLSQLBlock = regexp.MustCompile(`(?is)^(?:BEGIN|DECLARE|CREATE\s+(?:OR\s+REPLACE\s+)?(?:(?:NON)?EDITIONABLE\s+)?(?:FUNCTION|PACKAGE|PROCEDURE|TRIGGER|TYPE\s+BODY)\b)`)
+)
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+/*
+Split SQL text into statements in the manner of the database's own command line tool.
+
+  - All databases: statements end with ";" outside of quotes and comments.
+  - mssql: lines holding only "GO" separate batches.  A batch that starts with a procedural
+    statement, such as CREATE PROCEDURE, is sent whole.
+  - oci: a PL/SQL block, such as BEGIN ... END; or CREATE OR REPLACE PROCEDURE, ends with a line holding only "/".
+  - postgresql: ";" inside dollar-quoted strings does not end a statement.
+
+Text without any statement terminator is read one statement per line, as Senzing's own schema files are written.
+*/
+func splitSQL(sqlText string, scheme string) []string {
+	result := []string{}
+	chunks := []string{sqlText}
+	if scheme == "mssql" {
+		chunks = regexpBatchSeparator.Split(sqlText, -1)
+	}
+	for _, chunk := range chunks {
+		if scheme == "mssql" && regexpProceduralBatch.MatchString(stripLeadingComments(chunk)) {
+			if statement := strings.TrimSpace(chunk); len(statement) > 0 {
+				result = append(result, statement)
+			}
+			continue
+		}
+		statements, terminated := splitStatements(chunk, scheme)
+		if !terminated {
+			statements = splitLines(chunk)
+		}
+		result = append(result, statements...)
+	}
+	return result
+}
+
+// Split on ";" outside of quotes and comments.  Reports whether any terminator was found.
+func splitStatements(sqlText string, scheme string) ([]string, bool) {
+	result := []string{}
+	terminated := false
+	start := 0
+	add := func(end int) {
+		statement := strings.TrimSpace(sqlText[start:end])
+		if len(stripLeadingComments(statement)) > 0 {
+			result = append(result, statement)
+		}
+	}
+	for index := 0; index < len(sqlText); index++ {
+
+		// An Oracle PL/SQL block runs to the next "/" line.
+
+		if scheme == "oci" && index == start && regexpPLSQLBlock.MatchString(stripLeadingComments(sqlText[start:])) {
+			location := regexpBlockTerminator.FindStringIndex(sqlText[start:])
+			terminated = true
+			if location == nil {
+				add(len(sqlText))
+				return result, terminated
+			}
+			add(start + location[0])
+			start += location[1]
+			index = start - 1
+			continue
+		}
+
+		switch character := sqlText[index]; {
+		case character == ';':
+			add(index)
+			terminated = true
+			start = index + 1
+		case character == '\'' || character == '"' || (character == '`' && scheme == "mysql"):
+			index = skipQuoted(sqlText, index, character)
+		case character == '-' && strings.HasPrefix(sqlText[index:], "--"):
+			index = skipTo(sqlText, index, "\n")
+		case character == '/' && strings.HasPrefix(sqlText[index:], "/*"):
+			index = skipTo(sqlText, index+2, "*/")
+		case character == '/' && scheme == "oci" && isAloneOnLine(sqlText, index):
+			add(index) // SQL*Plus also accepts "/" in place of ";".
+			terminated = true
+			start = index + 1
+		case character == '$' && scheme == "postgresql":
+			if tag := regexpDollarQuote.FindString(sqlText[index:]); len(tag) > 0 && (index == 0 || !isIdentifierCharacter(sqlText[index-1])) {
+				index = skipTo(sqlText, index+len(tag), tag)
+			}
+		}
+	}
+	if start < len(sqlText) {
+		add(len(sqlText))
+	}
+	return result, terminated
+}
+
+// One statement per non-empty, non-comment line.
+func splitLines(sqlText string) []string {
+	result := []string{}
+	for _, line := range strings.Split(sqlText, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "--") {
+			continue
+		}
+		result = append(result, line)
+	}
+	return result
+}
+
+// Whether a character is the only non-blank character on its line.
+func isAloneOnLine(sqlText string, index int) bool {
+	lineStart := strings.LastIndexByte(sqlText[:index], '\n') + 1
+	lineEnd := strings.IndexByte(sqlText[index:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(sqlText)
+	} else {
+		lineEnd += index
+	}
+	return strings.TrimSpace(sqlText[lineStart:lineEnd]) == string(sqlText[index])
+}
+
+// Characters that may appear in an unquoted identifier.
+func isIdentifierCharacter(character byte) bool {
+	return character == '_' || (character >= '0' && character <= '9') || (character >= 'A' && character <= 'Z') || (character >= 'a' && character <= 'z')
+}
+
+// The index of the closing quote of a quoted string or identifier.  A doubled quote does not close it.
+func skipQuoted(sqlText string, index int, quote byte) int {
+	for index++; index < len(sqlText); index++ {
+		if sqlText[index] != quote {
+			continue
+		}
+		if index+1 < len(sqlText) && sqlText[index+1] == quote {
+			index++
+			continue
+		}
+		return index
+	}
+	return index
+}
+
+// The index of the last character of the next occurrence of a marker, or the end of the text.
+func skipTo(sqlText string, index int, marker string) int {
+	location := strings.Index(sqlText[index:], marker)
+	if location < 0 {
+		return len(sqlText)
+	}
+	return index + location + len(marker) - 1
+}
+
+// Text without leading whitespace and comments.
+func stripLeadingComments(sqlText string) string {
+	for {
+		sqlText = strings.TrimSpace(sqlText)
+		switch {
+		case strings.HasPrefix(sqlText, "--"):
+			sqlText = sqlText[min(skipTo(sqlText, 0, "\n")+1, len(sqlText)):]
+		case strings.HasPrefix(sqlText, "/*"):
+			sqlText = sqlText[min(skipTo(sqlText, 2, "*/")+1, len(sqlText)):]
+		default:
+			return sqlText
+		}
+	}
+}
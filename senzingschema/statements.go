@@ -0,0 +1,103 @@
+package senzingschema
+
+import (
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Kinds of SQL statements found in a Senzing schema file.
+const (
+	statementKindOther = iota
+	statementKindCreateTable
+	statementKindCreateIndex
+	statementKindAlterTable
+	statementKindInsert
+)
+
+// A single SQL statement from a Senzing schema file.
+type sqlStatement struct {
+	Kind   int    // One of the statementKind* constants.
+	Object string // Name of the table or index created. Empty for other statements.
+	Schema string // Schema qualifying Object and Table. Empty for the connection's default schema.
+	Table  string // Name of the table the statement acts upon.
+	SQL    string // The text sent to the database.
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var (
+	regexpAlterTable  = regexp.MustCompile(`(?i)^\s*ALTER\s+TABLE\s+([^\s(]+)`)
+	regexpCreateIndex = regexp.MustCompile(`(?i)^\s*CREATE\s+(?:UNIQUE\s+)?(?:(?:NON)?CLUSTERED\s+)?INDEX\s+([^\s(]+)\s+ON\s+([^\s(]+)`)
+	regexpCreateTable = regexp.MustCompile(`(?i)^\s*CREATE\s+TABLE\s+([^\s(]+)`)
+	regexpInsert      = regexp.MustCompile(`(?i)^\s*INSERT\s+INTO\s+([^\s(]+)`)
+)
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Classify a line of SQL by the kind of database object it manipulates.
+func classifyStatement(sqlText string) sqlStatement {
+	result := sqlStatement{
+		Kind: statementKindOther,
+		SQL:  sqlText,
+	}
+	if match := regexpCreateTable.FindStringSubmatch(sqlText); match != nil {
+		result.Kind = statementKindCreateTable
+		result.Object = normalizeObjectName(match[1])
+		result.Schema = normalizeSchemaName(match[1])
+		result.Table = result.Object
+	} else if match := regexpCreateIndex.FindStringSubmatch(sqlText); match != nil {
+		result.Kind = statementKindCreateIndex
+		result.Object = normalizeObjectName(match[1])
+		result.Schema = normalizeSchemaName(match[2])
+		result.Table = normalizeObjectName(match[2])
+	} else if match := regexpAlterTable.FindStringSubmatch(sqlText); match != nil {
+		result.Kind = statementKindAlterTable
+		result.Schema = normalizeSchemaName(match[1])
+		result.Table = normalizeObjectName(match[1])
+	} else if match := regexpInsert.FindStringSubmatch(sqlText); match != nil {
+		result.Kind = statementKindInsert
+		result.Schema = normalizeSchemaName(match[1])
+		result.Table = normalizeObjectName(match[1])
+	}
+	return result
+}
+
+// Database catalogs differ in case and quoting; compare names in upper case without quotes or schema.
+func normalizeObjectName(name string) string {
+	name = strings.Trim(name, "\"`[]")
+	if index := strings.LastIndex(name, "."); index >= 0 {
+		name = strings.Trim(name[index+1:], "\"`[]")
+	}
+	return strings.ToUpper(name)
+}
+
+// The schema qualifying a name, normalized like normalizeObjectName.  Empty when the name is unqualified.
+func normalizeSchemaName(name string) string {
+	index := strings.LastIndex(name, ".")
+	if index < 0 {
+		return ""
+	}
+	return normalizeObjectName(name[:index])
+}
+
+// Read SQL statements, split in the manner of the database's own command line tool.
+func parseSQL(reader io.Reader, scheme string) ([]sqlStatement, error) {
+	result := []sqlStatement{}
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return result, err
+	}
+	for _, sqlText := range splitSQL(string(content), scheme) {
+		result = append(result, classifyStatement(sqlText))
+	}
+	return result, err
+}
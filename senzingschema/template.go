@@ -0,0 +1,95 @@
+package senzingschema
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+/*
+Render a SQL file as a Go text/template, with SQLVariables as its data.
+Example: CREATE TABLE {{.Schema}}.RES_ENT (...) {{.TableOptions}};
+
+Every variable the file uses must be set; unresolved variables are reported together, before any SQL runs.
+Files without "{{" are returned unchanged.
+*/
+func renderSQL(sqlFile string, sqlText string, variables map[string]string) (string, error) {
+	if !strings.Contains(sqlText, "{{") {
+		return sqlText, nil
+	}
+	sqlTemplate, err := template.New(filepath.Base(sqlFile)).Option("missingkey=error").Parse(sqlText)
+	if err != nil {
+		return "", fmt.Errorf("invalid SQL template %s: %w", sqlFile, err)
+	}
+	unresolved := []string{}
+	for _, name := range templateVariables(sqlTemplate.Tree.Root) {
+		if _, ok := variables[name]; !ok {
+			unresolved = append(unresolved, name)
+		}
+	}
+	if len(unresolved) > 0 {
+		return "", fmt.Errorf("unresolved SQL variables in %s: %s", sqlFile, strings.Join(unresolved, ", "))
+	}
+	var result strings.Builder
+	err = sqlTemplate.Execute(&result, variables)
+	if err != nil {
+		return "", fmt.Errorf("cannot render SQL template %s: %w", sqlFile, err)
+	}
+	return result.String(), err
+}
+
+// The sorted, distinct names of the {{.Name}} fields used in a template.
+func templateVariables(root parse.Node) []string {
+	names := map[string]bool{}
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch node := node.(type) {
+		case *parse.ListNode:
+			if node == nil {
+				return
+			}
+			for _, child := range node.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			walk(node.Pipe)
+		case *parse.IfNode:
+			walk(node.Pipe)
+			walk(node.List)
+			walk(node.ElseList)
+		case *parse.RangeNode:
+			walk(node.Pipe) // Inside the body, "." is no longer the variables.
+			walk(node.ElseList)
+		case *parse.WithNode:
+			walk(node.Pipe) // Inside the body, "." is no longer the variables.
+			walk(node.ElseList)
+		case *parse.PipeNode:
+			if node == nil {
+				return
+			}
+			for _, command := range node.Cmds {
+				walk(command)
+			}
+		case *parse.CommandNode:
+			for _, argument := range node.Args {
+				walk(argument)
+			}
+		case *parse.FieldNode:
+			names[node.Ident[0]] = true
+		}
+	}
+	walk(root)
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
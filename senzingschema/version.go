@@ -0,0 +1,127 @@
+package senzingschema
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// How an installed Senzing schema version relates to the version in a SQL file.
+const (
+	schemaVersionUnknown = iota
+	schemaVersionSame
+	schemaVersionOlder
+	schemaVersionNewer
+	schemaVersionIncompatible
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Name of the table holding the installed schema version.
+const versionTable = "SYS_VARS"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var regexpSchemaVersion = regexp.MustCompile(`(?i)VALUES\s*\(\s*'VERSION'\s*,\s*'SCHEMA'\s*,\s*'([^']*)'`)
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Compare an installed schema version with the version declared by a SQL file.
+// Versions with a different major number are incompatible.
+func compareSchemaVersions(installed string, declared string) int {
+	if len(installed) == 0 || len(declared) == 0 {
+		return schemaVersionUnknown
+	}
+	installedParts, err := parseSchemaVersion(installed)
+	if err != nil {
+		return schemaVersionIncompatible
+	}
+	declaredParts, err := parseSchemaVersion(declared)
+	if err != nil {
+		return schemaVersionUnknown
+	}
+	if installedParts[0] != declaredParts[0] {
+		return schemaVersionIncompatible
+	}
+	for index := range installedParts {
+		switch {
+		case installedParts[index] > declaredParts[index]:
+			return schemaVersionNewer
+		case installedParts[index] < declaredParts[index]:
+			return schemaVersionOlder
+		}
+	}
+	return schemaVersionSame
+}
+
+// The schema version a SQL file inserts into SYS_VARS, or "" if it does not insert one.
+func declaredSchemaVersion(statements []sqlStatement) string {
+	_, result := findSchemaVersionStatement(statements)
+	return result
+}
+
+// The statement of a SQL file that inserts the schema version into SYS_VARS, and the version it inserts.
+func findSchemaVersionStatement(statements []sqlStatement) (sqlStatement, string) {
+	for _, statement := range statements {
+		if statement.Kind != statementKindInsert || statement.Table != versionTable {
+			continue
+		}
+		if match := regexpSchemaVersion.FindStringSubmatch(statement.SQL); match != nil {
+			return statement, match[1]
+		}
+	}
+	return sqlStatement{}, ""
+}
+
+// The schema version recorded in SYS_VARS, or "" if there is none.
+// The schema qualifies SYS_VARS; it is empty for the connection's default schema.
+func getInstalledSchemaVersion(ctx context.Context, database *sql.DB, catalog *databaseCatalog, schema string) (string, error) {
+	var result string
+	table := qualifiedName(schema, versionTable)
+	if !catalog.tables[table] {
+		return result, nil
+	}
+	row := database.QueryRowContext(ctx, "SELECT VAR_VALUE FROM "+table+" WHERE VAR_GROUP = 'VERSION' AND VAR_CODE = 'SCHEMA'")
+	err := row.Scan(&result)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return strings.TrimSpace(result), err
+}
+
+// The schema qualifying SYS_VARS where a SQL file inserts the schema version.  Empty for the connection's default schema.
+func schemaVersionTableSchema(statements []sqlStatement) string {
+	statement, _ := findSchemaVersionStatement(statements)
+	return statement.Schema
+}
+
+// Split "major.minor[.patch]" into three numbers; missing parts are zero.
+func parseSchemaVersion(version string) ([3]int, error) {
+	var result [3]int
+	parts := strings.Split(strings.TrimSpace(version), ".")
+	if len(parts) > len(result) {
+		return result, fmt.Errorf("invalid schema version: %s", version)
+	}
+	for index, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return result, fmt.Errorf("invalid schema version: %s", version)
+		}
+		result[index] = number
+	}
+	return result, nil
+}
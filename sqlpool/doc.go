@@ -0,0 +1,4 @@
+/*
+Package sqlpool opens and closes the database/sql pools init-database uses to inspect and change Senzing databases.
+*/
+package sqlpool
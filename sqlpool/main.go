@@ -0,0 +1,58 @@
+package sqlpool
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/senzing-garage/go-databasing/connector"
+	"github.com/senzing-garage/go-databasing/dbhelper"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+Close closes a pool opened by Open.
+The pool of a SQLite in-memory database is left open, because closing its last connection
+would discard the database before Senzing uses it.
+
+Input
+  - database: The pool to close.  May be nil.
+  - databaseURL: The URL the pool was opened with.
+*/
+func Close(database *sql.DB, databaseURL string) error {
+	if database == nil || IsInMemory(databaseURL) {
+		return nil
+	}
+	return database.Close()
+}
+
+/*
+IsInMemory reports whether a database URL names a SQLite in-memory database.
+
+Input
+  - databaseURL: A database URL, in Senzing's form or the standard form.
+*/
+func IsInMemory(databaseURL string) bool {
+	parsedURL, err := dbhelper.ParseDatabaseURL(databaseURL)
+	if err != nil {
+		return false
+	}
+	return parsedURL.Scheme == "sqlite3" && parsedURL.Query().Get("mode") == "memory"
+}
+
+/*
+Open opens a pool on a database URL.  Release it with Close.
+
+Input
+  - ctx: A context to control cancellation.
+  - databaseURL: A database URL, in Senzing's form or the standard form.
+*/
+func Open(ctx context.Context, databaseURL string) (*sql.DB, error) {
+	databaseConnector, err := connector.NewConnector(ctx, databaseURL)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(databaseConnector), err
+}